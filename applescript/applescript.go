@@ -0,0 +1,169 @@
+// Package applescript runs AppleScript, JavaScript for Automation (JXA) and
+// helper commands through osascript and friends, and parses the plain-text
+// values they return.
+//
+// The other packages never execute processes themselves; they receive a
+// ScriptRunner and/or CommandRunner so that tool logic can be exercised with
+// the fake in package applescripttest instead of a live macOS GUI session.
+package applescript
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ScriptRunner executes AppleScript and JXA source and returns its trimmed
+// output.
+type ScriptRunner interface {
+	RunAppleScript(ctx context.Context, script string) (string, error)
+	RunJXA(ctx context.Context, script string) (string, error)
+}
+
+// CommandRunner executes an external command (e.g. system_profiler) and
+// returns its trimmed combined output.
+type CommandRunner interface {
+	RunCommand(ctx context.Context, name string, args ...string) (string, error)
+}
+
+// Runner is a ScriptRunner that can also run commands.
+type Runner interface {
+	ScriptRunner
+	CommandRunner
+}
+
+// Exec is the Runner backed by os/exec.
+//
+// Every call runs in its own process group and is bounded by Timeout
+// (DefaultTimeout when zero). On timeout or cancellation the whole group is
+// killed, so an osascript blocked on an unresponsive app cannot outlive the
+// call. Calls in flight are recorded in Tracker when it is set.
+type Exec struct {
+	Timeout time.Duration
+	Tracker *Tracker
+}
+
+var _ Runner = Exec{}
+
+// RunAppleScript executes an AppleScript via `osascript -e`.
+func (e Exec) RunAppleScript(ctx context.Context, script string) (string, error) {
+	out, err := e.run(ctx, "osascript", "-e", script)
+	if err != nil {
+		return "", fmt.Errorf("osascript error: %w (output: %s)", err, out)
+	}
+	return out, nil
+}
+
+// RunJXA executes a JavaScript for Automation script. JXA gives access to the
+// Objective-C bridge (CoreGraphics, AppKit) for data AppleScript can't reach.
+func (e Exec) RunJXA(ctx context.Context, script string) (string, error) {
+	out, err := e.run(ctx, "osascript", "-l", "JavaScript", "-e", script)
+	if err != nil {
+		return "", fmt.Errorf("osascript (JXA) error: %w (output: %s)", err, out)
+	}
+	return out, nil
+}
+
+// RunCommand executes name with args.
+func (e Exec) RunCommand(ctx context.Context, name string, args ...string) (string, error) {
+	out, err := e.run(ctx, name, args...)
+	if err != nil {
+		return "", fmt.Errorf("command error: %w (output: %s)", err, out)
+	}
+	return out, nil
+}
+
+// run starts name in a new process group, waits for it within the timeout
+// and returns its trimmed combined output.
+func (e Exec) run(ctx context.Context, name string, args ...string) (string, error) {
+	timeout := e.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error { return KillGroup(cmd.Process.Pid) }
+	cmd.WaitDelay = killGrace
+
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+	e.Tracker.add(cmd.Process.Pid, name)
+	err := cmd.Wait()
+	e.Tracker.remove(cmd.Process.Pid)
+
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("killed after %s timeout: %w", timeout, err)
+	}
+	return strings.TrimSpace(out.String()), err
+}
+
+// automationDeniedCodes are the AppleScript errors macOS returns when the
+// calling app may not drive System Events: -1743 when Automation permission
+// is denied, -1719 when Accessibility ("assistive access") is not granted.
+var automationDeniedCodes = []string{"(-1743)", "(-1719)"}
+
+// IsAutomationDenied reports whether err from RunAppleScript means macOS
+// blocked the script from controlling another app, as opposed to the script
+// itself failing.
+func IsAutomationDenied(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, code := range automationDeniedCodes {
+		if strings.Contains(msg, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// Quote returns s as an AppleScript string literal, escaping backslashes and
+// double quotes.
+func Quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// ParseCSVInts parses exactly n comma-separated integers, as produced by
+// AppleScript list concatenation such as `x & "," & y`.
+func ParseCSVInts(s string, n int) ([]int, error) {
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, ",")
+
+	// Filter out empty parts after trimming
+	var nonEmptyParts []string
+	for _, p := range parts {
+		trimmed := strings.TrimSpace(p)
+		if trimmed != "" {
+			nonEmptyParts = append(nonEmptyParts, trimmed)
+		}
+	}
+
+	if len(nonEmptyParts) != n {
+		return nil, fmt.Errorf("expected %d comma-separated values, got %d (%q)", n, len(nonEmptyParts), s)
+	}
+	out := make([]int, n)
+	for i, p := range nonEmptyParts {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int at position %d: %q (%w)", i, p, err)
+		}
+		out[i] = v
+	}
+	return out, nil
+}
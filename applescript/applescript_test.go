@@ -0,0 +1,65 @@
+package applescript
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseCSVInts(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		n       int
+		want    []int
+		wantErr bool
+	}{
+		{name: "plain", in: "1,2,3,4", n: 4, want: []int{1, 2, 3, 4}},
+		{name: "spaces and newline", in: " -1440, 25 ,0,900\n", n: 4, want: []int{-1440, 25, 0, 900}},
+		{name: "empty parts ignored", in: "10,,20", n: 2, want: []int{10, 20}},
+		{name: "too few", in: "1,2,3", n: 4, wantErr: true},
+		{name: "not an int", in: "1,x", n: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCSVInts(tt.in, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCSVInts(%q, %d) error = %v, wantErr %v", tt.in, tt.n, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCSVInts(%q, %d) = %v, want %v", tt.in, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := map[string]string{
+		"f":          `"f"`,
+		`"`:          `"\""`,
+		`\`:          `"\\"`,
+		`say "hi" \`: `"say \"hi\" \\"`,
+	}
+	for in, want := range tests {
+		if got := Quote(in); got != want {
+			t.Errorf("Quote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestIsAutomationDenied(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("osascript error: exit status 1 (output: execution error: Not authorized to send Apple events to System Events. (-1743))"), true},
+		{errors.New("osascript error: exit status 1 (output: execution error: System Events got an error: osascript is not allowed assistive access. (-1719))"), true},
+		{errors.New("osascript error: exit status 1 (output: execution error: Application 'Safari' is not running. (-2700))"), false},
+	}
+	for _, tt := range tests {
+		if got := IsAutomationDenied(tt.err); got != tt.want {
+			t.Errorf("IsAutomationDenied(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Package applescripttest provides a fake applescript.Runner that records
+// every script and command instead of executing it, plus golden-file helpers
+// for asserting generated scripts.
+package applescripttest
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata/")
+
+// Kind identifies what a Call ran.
+type Kind string
+
+const (
+	AppleScript Kind = "applescript"
+	JXA         Kind = "jxa"
+	Command     Kind = "command"
+)
+
+// Call is one recorded invocation.
+type Call struct {
+	Kind Kind
+	// Script is the source for AppleScript and JXA calls.
+	Script string
+	// Name and Args are set for Command calls.
+	Name string
+	Args []string
+}
+
+// Runner is a fake applescript.Runner. Respond decides what each call
+// returns; when nil every call succeeds with empty output.
+type Runner struct {
+	Respond func(Call) (string, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ applescript.Runner = (*Runner)(nil)
+
+func (r *Runner) record(c Call) (string, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, c)
+	respond := r.Respond
+	r.mu.Unlock()
+
+	if respond == nil {
+		return "", nil
+	}
+	return respond(c)
+}
+
+// RunAppleScript records an AppleScript call.
+func (r *Runner) RunAppleScript(ctx context.Context, script string) (string, error) {
+	return r.record(Call{Kind: AppleScript, Script: script})
+}
+
+// RunJXA records a JXA call.
+func (r *Runner) RunJXA(ctx context.Context, script string) (string, error) {
+	return r.record(Call{Kind: JXA, Script: script})
+}
+
+// RunCommand records a command call.
+func (r *Runner) RunCommand(ctx context.Context, name string, args ...string) (string, error) {
+	return r.record(Call{Kind: Command, Name: name, Args: args})
+}
+
+// Calls returns the calls recorded so far.
+func (r *Runner) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Scripts returns the sources of the recorded AppleScript and JXA calls.
+func (r *Runner) Scripts() []string {
+	var scripts []string
+	for _, c := range r.Calls() {
+		if c.Kind != Command {
+			scripts = append(scripts, c.Script)
+		}
+	}
+	return scripts
+}
+
+// Outputs returns a Respond func that answers calls in order with the given
+// outputs, and with empty output once they run out.
+func Outputs(outputs ...string) func(Call) (string, error) {
+	var mu sync.Mutex
+	return func(Call) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(outputs) == 0 {
+			return "", nil
+		}
+		out := outputs[0]
+		outputs = outputs[1:]
+		return out, nil
+	}
+}
+
+// Golden compares got with testdata/<name>.golden, rewriting the file instead
+// when the test binary runs with -update.
+func Golden(t testing.TB, name, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if strings.TrimSpace(string(want)) != strings.TrimSpace(got) {
+		t.Errorf("%s mismatch (run with -update to accept)\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
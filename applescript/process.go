@@ -0,0 +1,269 @@
+package applescript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultTimeout bounds a single Exec call. System Events queries normally
+// finish in well under a second; one that takes this long is waiting on an
+// app that stopped responding.
+const DefaultTimeout = 30 * time.Second
+
+// killGrace is how long Exec waits for output pipes to close after killing a
+// process group (exec.Cmd.WaitDelay).
+const killGrace = 2 * time.Second
+
+// Process is a call Exec is waiting on.
+type Process struct {
+	PID     int       `json:"pid" jsonschema:"Process ID"`
+	Name    string    `json:"name" jsonschema:"Executable, e.g. osascript or system_profiler"`
+	Started time.Time `json:"started" jsonschema:"When the process was started"`
+}
+
+// Tracker records the processes Exec has started and not yet collected. A
+// nil *Tracker records nothing.
+type Tracker struct {
+	mu    sync.Mutex
+	procs map[int]Process
+}
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{procs: make(map[int]Process)}
+}
+
+func (t *Tracker) add(pid int, name string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.procs[pid] = Process{PID: pid, Name: name, Started: time.Now()}
+}
+
+func (t *Tracker) remove(pid int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.procs, pid)
+}
+
+// Running returns the processes in flight, oldest first.
+func (t *Tracker) Running() []Process {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]Process, 0, len(t.procs))
+	for _, p := range t.procs {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Started.Before(out[j].Started) })
+	return out
+}
+
+// KillGroup sends SIGKILL to the process group led by pid, falling back to
+// the process alone when it does not lead a group (started before Exec used
+// process groups).
+func KillGroup(pid int) error {
+	err := syscall.Kill(-pid, syscall.SIGKILL)
+	if errors.Is(err, syscall.ESRCH) {
+		err = syscall.Kill(pid, syscall.SIGKILL)
+	}
+	return err
+}
+
+// Reasons a Leftover was found.
+const (
+	ReasonZombie   = "zombie"   // exited, waiting for its parent (shown in PPID) to collect it
+	ReasonStuck    = "stuck"    // child of this server running longer than the threshold
+	ReasonOrphaned = "orphaned" // reparented to launchd, e.g. after a server crash
+)
+
+// Actions taken on a Leftover.
+const (
+	ActionKilled   = "killed"
+	ActionReported = "reported"
+	ActionFailed   = "failed"
+)
+
+// Leftover is an osascript or system_profiler process that should no longer
+// exist.
+type Leftover struct {
+	PID     int    `json:"pid" jsonschema:"Process ID"`
+	PPID    int    `json:"ppid" jsonschema:"Parent process ID"`
+	Name    string `json:"name" jsonschema:"Executable name"`
+	State   string `json:"state" jsonschema:"ps state, Z for zombie"`
+	Seconds int    `json:"seconds" jsonschema:"How long the process has existed"`
+	Reason  string `json:"reason" jsonschema:"zombie, stuck or orphaned"`
+	Action  string `json:"action" jsonschema:"killed, reported or failed"`
+	Error   string `json:"error,omitempty" jsonschema:"Why the action failed"`
+}
+
+// spawnedNames are the executables this server runs.
+var spawnedNames = map[string]bool{"osascript": true, "system_profiler": true}
+
+// psArgs lists every process with the columns parsePS reads.
+var psArgs = []string{"-axo", "pid=,ppid=,stat=,etime=,comm="}
+
+// Reaper finds and removes osascript and system_profiler processes left
+// behind by crashed or hung calls. It never waits on processes: the server's
+// own children are collected by the exec.Cmd that started them, and a wait4
+// here would steal that exit status. Kill defaults to KillGroup; tests
+// replace it.
+type Reaper struct {
+	runner CommandRunner
+	self   int
+
+	// Tracker, when set, holds the calls in flight.
+	Tracker *Tracker
+	Kill    func(pid int) error
+}
+
+// NewReaper returns a Reaper that lists processes through r.
+func NewReaper(r CommandRunner) *Reaper {
+	return &Reaper{runner: r, self: os.Getpid(), Kill: KillGroup}
+}
+
+// Cleanup lists leftover processes older than olderThan and, unless dryRun,
+// kills stuck ones. Children of this server are never treated as stuck
+// before DefaultTimeout, however small olderThan is, so calls still inside
+// their deadline survive. Zombies of this server or launchd are only
+// reported: only their parent can collect them, Exec or launchd does. Orphans
+// from earlier server runs are only killed with killOrphans, since they
+// cannot be told apart from scripts the user runs.
+func (rp *Reaper) Cleanup(ctx context.Context, olderThan time.Duration, killOrphans, dryRun bool) ([]Leftover, error) {
+	out, err := rp.runner.RunCommand(ctx, "ps", psArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+	procs, err := parsePS(out)
+	if err != nil {
+		return nil, err
+	}
+
+	stuckAfter := max(olderThan, DefaultTimeout)
+	var leftovers []Leftover
+	for _, p := range procs {
+		if !spawnedNames[p.Name] {
+			continue
+		}
+		age := time.Duration(p.Seconds) * time.Second
+		zombie := strings.HasPrefix(p.State, "Z")
+		switch {
+		case zombie && (p.PPID == rp.self || p.PPID == 1):
+			p.Reason = ReasonZombie
+		case zombie:
+			continue
+		case p.PPID == rp.self && age >= stuckAfter:
+			p.Reason = ReasonStuck
+		case p.PPID == 1 && age >= olderThan:
+			p.Reason = ReasonOrphaned
+		default:
+			continue
+		}
+		p.Action = ActionReported
+		if !dryRun {
+			rp.act(&p, killOrphans)
+		}
+		leftovers = append(leftovers, p)
+	}
+	return leftovers, nil
+}
+
+// act kills p according to its reason and records the outcome. Zombies stay
+// reported.
+func (rp *Reaper) act(p *Leftover, killOrphans bool) {
+	var err error
+	switch p.Reason {
+	case ReasonStuck:
+		if err = rp.Kill(p.PID); err == nil {
+			p.Action = ActionKilled
+		}
+	case ReasonOrphaned:
+		if !killOrphans {
+			return
+		}
+		if err = rp.Kill(p.PID); err == nil {
+			p.Action = ActionKilled
+		}
+	}
+	if err != nil {
+		p.Action = ActionFailed
+		p.Error = err.Error()
+	}
+}
+
+// parsePS parses `ps -o pid=,ppid=,stat=,etime=,comm=` output. comm may be a
+// full path containing spaces, so it is everything after the fourth column;
+// macOS shows zombies as "(name)".
+func parsePS(out string) ([]Leftover, error) {
+	var procs []Leftover
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("unexpected ps line %q", line)
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pid in ps line %q: %w", line, err)
+		}
+		ppid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid ppid in ps line %q: %w", line, err)
+		}
+		secs, err := parseElapsed(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid elapsed time in ps line %q: %w", line, err)
+		}
+		procs = append(procs, Leftover{
+			PID:     pid,
+			PPID:    ppid,
+			State:   fields[2],
+			Seconds: secs,
+			Name:    strings.Trim(filepath.Base(strings.Join(fields[4:], " ")), "()"),
+		})
+	}
+	return procs, nil
+}
+
+// parseElapsed converts a ps etime value, [[dd-]hh:]mm:ss, to seconds.
+func parseElapsed(s string) (int, error) {
+	days := 0
+	if d, rest, ok := strings.Cut(s, "-"); ok {
+		n, err := strconv.Atoi(d)
+		if err != nil {
+			return 0, err
+		}
+		days, s = n, rest
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("expected [[dd-]hh:]mm:ss, got %q", s)
+	}
+	secs := 0
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, err
+		}
+		secs = secs*60 + n
+	}
+	return days*86400 + secs, nil
+}
@@ -0,0 +1,231 @@
+package applescript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// psRunner answers every command with a fixed ps listing.
+type psRunner string
+
+func (r psRunner) RunCommand(context.Context, string, ...string) (string, error) {
+	return string(r), nil
+}
+
+func TestParseElapsed(t *testing.T) {
+	tests := map[string]int{
+		"00:05":       5,
+		"01:30":       90,
+		"02:00:01":    7201,
+		"1-00:00:00":  86400,
+		"3-04:05:06":  3*86400 + 4*3600 + 5*60 + 6,
+		"bad":         -1,
+		"1:2:3:4":     -1,
+		"x-00:00:01":  -1,
+		"00:xx":       -1,
+		"12-00:00:xx": -1,
+	}
+	for in, want := range tests {
+		got, err := parseElapsed(in)
+		if want < 0 {
+			if err == nil {
+				t.Errorf("parseElapsed(%q) = %d, want error", in, got)
+			}
+			continue
+		}
+		if err != nil || got != want {
+			t.Errorf("parseElapsed(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+}
+
+func TestParsePS(t *testing.T) {
+	out := `  101     1 Ss   1-02:00:00 /sbin/launchd
+  202   100 S       00:03 /usr/bin/osascript
+  303   100 Z       00:00 (osascript)
+  404     1 S       05:00 /Applications/My App.app/Contents/MacOS/My App
+`
+	got, err := parsePS(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, p := range got {
+		names = append(names, p.Name)
+	}
+	want := []string{"launchd", "osascript", "osascript", "My App"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %q, want %q", names, want)
+	}
+	if got[0].Seconds != 93600 || got[1].PPID != 100 || got[2].State != "Z" {
+		t.Errorf("parsePS fields = %+v", got)
+	}
+
+	if _, err := parsePS("12 1 S"); err == nil {
+		t.Error("short line: want error")
+	}
+}
+
+func TestReaperCleanup(t *testing.T) {
+	const self = 100
+	ps := `  201   100 S       02:00 /usr/bin/osascript
+  202   100 S       00:05 /usr/bin/osascript
+  203   100 Z       00:00 (osascript)
+  204   100 Z       00:00 osascript
+  205     1 S    01:00:00 /usr/bin/osascript
+  206     1 S    01:00:00 /usr/sbin/system_profiler
+  207   100 S       09:00 /bin/ps
+  208     1 Z       09:00 osascript
+  209   555 S       09:00 /usr/bin/osascript
+  210   100 S       00:29 /usr/bin/osascript
+  211   555 Z       00:00 osascript
+`
+	newReaper := func() (*Reaper, *[]int) {
+		var killed []int
+		tr := NewTracker()
+		tr.add(202, "osascript")
+		tr.add(210, "osascript")
+		rp := &Reaper{
+			runner:  psRunner(ps),
+			self:    self,
+			Tracker: tr,
+			Kill: func(pid int) error {
+				if pid == 206 {
+					return errors.New("operation not permitted")
+				}
+				killed = append(killed, pid)
+				return nil
+			},
+		}
+		return rp, &killed
+	}
+
+	summarize := func(ls []Leftover) []string {
+		var out []string
+		for _, l := range ls {
+			out = append(out, fmt.Sprintf("%d %s %s", l.PID, l.Reason, l.Action))
+		}
+		return out
+	}
+
+	tests := []struct {
+		name        string
+		olderThan   time.Duration
+		killOrphans bool
+		dryRun      bool
+		want        []string
+		wantKilled  []int
+	}{
+		{
+			name:      "orphans reported only",
+			olderThan: time.Minute,
+			want: []string{
+				"201 stuck killed",
+				"203 zombie reported",
+				"204 zombie reported",
+				"205 orphaned reported",
+				"206 orphaned reported",
+				"208 zombie reported",
+			},
+			wantKilled: []int{201},
+		},
+		{
+			name:        "kill orphans",
+			olderThan:   time.Minute,
+			killOrphans: true,
+			want: []string{
+				"201 stuck killed",
+				"203 zombie reported",
+				"204 zombie reported",
+				"205 orphaned killed",
+				"206 orphaned failed",
+				"208 zombie reported",
+			},
+			wantKilled: []int{201, 205},
+		},
+		{
+			name:        "dry run",
+			olderThan:   time.Minute,
+			killOrphans: true,
+			dryRun:      true,
+			want: []string{
+				"201 stuck reported",
+				"203 zombie reported",
+				"204 zombie reported",
+				"205 orphaned reported",
+				"206 orphaned reported",
+				"208 zombie reported",
+			},
+		},
+		{
+			// Calls still inside DefaultTimeout survive a zero threshold.
+			name: "zero threshold spares calls in flight",
+			want: []string{
+				"201 stuck killed",
+				"203 zombie reported",
+				"204 zombie reported",
+				"205 orphaned reported",
+				"206 orphaned reported",
+				"208 zombie reported",
+			},
+			wantKilled: []int{201},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rp, killed := newReaper()
+			got, err := rp.Cleanup(context.Background(), tt.olderThan, tt.killOrphans, tt.dryRun)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := summarize(got); !reflect.DeepEqual(s, tt.want) {
+				t.Errorf("Cleanup = %q, want %q", s, tt.want)
+			}
+			for _, l := range got {
+				if l.Reason == ReasonZombie && l.PPID != self && l.PPID != 1 {
+					t.Errorf("zombie %d reported with parent %d", l.PID, l.PPID)
+				}
+			}
+			if !reflect.DeepEqual(*killed, tt.wantKilled) {
+				t.Errorf("killed = %v, want %v", *killed, tt.wantKilled)
+			}
+		})
+	}
+}
+
+func TestTrackerRunning(t *testing.T) {
+	tr := NewTracker()
+	tr.add(2, "osascript")
+	tr.add(1, "system_profiler")
+	tr.remove(2)
+	got := tr.Running()
+	if len(got) != 1 || got[0].PID != 1 || got[0].Name != "system_profiler" {
+		t.Errorf("Running() = %+v, want only pid 1", got)
+	}
+
+	var nilTracker *Tracker
+	nilTracker.add(3, "osascript")
+	if nilTracker.Running() != nil {
+		t.Error("nil Tracker recorded a process")
+	}
+}
+
+func TestExecKillsOnTimeout(t *testing.T) {
+	tr := NewTracker()
+	start := time.Now()
+	_, err := Exec{Timeout: 100 * time.Millisecond, Tracker: tr}.RunCommand(context.Background(), "sleep", "10")
+	if err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("err = %v, want timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("RunCommand returned after %s, want prompt kill", elapsed)
+	}
+	if running := tr.Running(); len(running) != 0 {
+		t.Errorf("Running() = %+v after the call returned", running)
+	}
+}
@@ -0,0 +1,157 @@
+// Package capability probes the macOS version and the facilities that change
+// how window management behaves (native tiling, Stage Manager, Screen
+// Recording permission, yabai).
+//
+// Behavior differs across Ventura / Sonoma / Sequoia. Probing once up front
+// lets callers explain those differences instead of failing with a bare
+// osascript error.
+package capability
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript"
+)
+
+// Capabilities is the result of a capability probe.
+type Capabilities struct {
+	MacOSVersion    string   `json:"macOSVersion" jsonschema:"macOS product version, e.g. '15.1'"`
+	MacOSMajor      int      `json:"macOSMajor" jsonschema:"macOS major version number"`
+	MacOSName       string   `json:"macOSName" jsonschema:"macOS marketing name, e.g. 'Sequoia'"`
+	NativeTiling    bool     `json:"nativeTiling" jsonschema:"Whether native window tiling is available (macOS 15+)"`
+	StageManager    bool     `json:"stageManager" jsonschema:"Whether Stage Manager is currently enabled"`
+	ScreenRecording bool     `json:"screenRecording" jsonschema:"Whether Screen Recording permission is granted (needed for screenshots and window titles from CoreGraphics)"`
+	Yabai           bool     `json:"yabai" jsonschema:"Whether the yabai window manager is installed"`
+	YabaiPath       string   `json:"yabaiPath,omitempty" jsonschema:"Path to the yabai executable, if found"`
+	Notes           []string `json:"notes,omitempty" jsonschema:"How the detected facilities affect window management tools"`
+}
+
+// Client probes capabilities through a Runner and caches the result.
+type Client struct {
+	run applescript.Runner
+
+	mu   sync.Mutex
+	caps *Capabilities
+}
+
+// New returns a Client that executes probes with r.
+func New(r applescript.Runner) *Client {
+	return &Client{run: r}
+}
+
+var macOSNames = map[int]string{
+	11: "Big Sur",
+	12: "Monterey",
+	13: "Ventura",
+	14: "Sonoma",
+	15: "Sequoia",
+	26: "Tahoe",
+}
+
+// yabaiSearchPaths covers Homebrew installs, which are often missing from
+// the PATH of GUI-launched MCP clients.
+var yabaiSearchPaths = []string{
+	"/opt/homebrew/bin/yabai",
+	"/usr/local/bin/yabai",
+}
+
+// yabaiLookupScript prints the path of the yabai executable, trying the PATH
+// first and then yabaiSearchPaths, and fails when there is none.
+var yabaiLookupScript = `command -v yabai || for p in ` + strings.Join(yabaiSearchPaths, " ") +
+	`; do [ -x "$p" ] && echo "$p" && exit 0; done; exit 1`
+
+// Get returns the cached capability probe, running Detect on first use (or
+// when refresh is set).
+func (c *Client) Get(ctx context.Context, refresh bool) (Capabilities, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.caps != nil && !refresh {
+		return *c.caps, nil
+	}
+	caps, err := c.Detect(ctx)
+	if err != nil {
+		return Capabilities{}, err
+	}
+	c.caps = &caps
+	return caps, nil
+}
+
+// Detect probes the system. Only the macOS version is mandatory; the other
+// probes report "unavailable" when they fail.
+func (c *Client) Detect(ctx context.Context) (Capabilities, error) {
+	version, err := c.run.RunCommand(ctx, "sw_vers", "-productVersion")
+	if err != nil {
+		return Capabilities{}, fmt.Errorf("failed to detect macOS version: %w", err)
+	}
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return Capabilities{}, fmt.Errorf("unexpected macOS version %q: %w", version, err)
+	}
+
+	caps := Capabilities{
+		MacOSVersion: version,
+		MacOSMajor:   major,
+		MacOSName:    macOSNames[major],
+		NativeTiling: major >= 15,
+	}
+
+	// The key is absent until Stage Manager has been toggled once, which
+	// makes `defaults read` fail; treat that as disabled.
+	if out, err := c.run.RunCommand(ctx, "defaults", "read", "com.apple.WindowManager", "GloballyEnabled"); err == nil {
+		caps.StageManager = out == "1"
+	}
+
+	if out, err := c.run.RunJXA(ctx, screenRecordingPreflightScript); err == nil {
+		caps.ScreenRecording = out == "true"
+	}
+
+	if out, err := c.run.RunCommand(ctx, "/bin/sh", "-c", yabaiLookupScript); err == nil {
+		caps.YabaiPath, _, _ = strings.Cut(strings.TrimSpace(out), "\n")
+	}
+	caps.Yabai = caps.YabaiPath != ""
+
+	caps.Notes = notes(caps)
+	return caps, nil
+}
+
+func notes(caps Capabilities) []string {
+	var notes []string
+	if caps.MacOSMajor < 13 {
+		notes = append(notes, fmt.Sprintf("macOS %s is older than Ventura; tools are only tested on Sonoma and later.", caps.MacOSVersion))
+	}
+	if caps.NativeTiling {
+		notes = append(notes, "Native tiling is available; dragging windows to screen edges may re-tile windows placed by these tools.")
+	}
+	if caps.StageManager {
+		notes = append(notes, "Stage Manager is enabled; macOS may shift or shrink windows to keep the recent-apps strip visible.")
+	}
+	if !caps.ScreenRecording {
+		notes = append(notes, "Screen Recording permission is not granted; screenshots and CoreGraphics window titles are unavailable.")
+	}
+	if caps.Yabai {
+		notes = append(notes, "yabai is installed; if it manages these windows it may override positions set by these tools.")
+	}
+	return notes
+}
+
+// PlacementNotes returns the capability notes that explain why a window may
+// not land exactly where it was put. It returns nil if the probe fails.
+func (c *Client) PlacementNotes(ctx context.Context) []string {
+	caps, err := c.Get(ctx, false)
+	if err != nil {
+		return nil
+	}
+	var notes []string
+	if caps.StageManager {
+		notes = append(notes, "Stage Manager is enabled and may adjust the final frame")
+	}
+	if caps.Yabai {
+		notes = append(notes, "yabai is installed and may override the final frame")
+	}
+	return notes
+}
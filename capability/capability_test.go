@@ -0,0 +1,124 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+func fakeSystem(version, stageManager string) func(applescripttest.Call) (string, error) {
+	return func(c applescripttest.Call) (string, error) {
+		switch {
+		case c.Kind == applescripttest.JXA:
+			return "false", nil
+		case c.Name == "sw_vers":
+			return version, nil
+		case c.Name == "defaults":
+			if stageManager == "" {
+				return "", errors.New("does not exist")
+			}
+			return stageManager, nil
+		}
+		return "", nil
+	}
+}
+
+func TestDetect(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeSystem("15.1", "1")}
+	caps, err := New(r).Detect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps.MacOSMajor != 15 || caps.MacOSName != "Sequoia" || !caps.NativeTiling {
+		t.Errorf("version fields = %+v", caps)
+	}
+	if !caps.StageManager {
+		t.Error("StageManager = false, want true")
+	}
+	if caps.ScreenRecording {
+		t.Error("ScreenRecording = true, want false")
+	}
+	if len(caps.Notes) == 0 {
+		t.Error("Notes is empty")
+	}
+	applescripttest.Golden(t, "screen_recording_preflight", r.Scripts()[0])
+}
+
+func TestDetectMissingStageManagerKey(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeSystem("14.6.1", "")}
+	caps, err := New(r).Detect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps.StageManager || caps.NativeTiling || caps.MacOSName != "Sonoma" {
+		t.Errorf("caps = %+v, want Sonoma without Stage Manager or tiling", caps)
+	}
+}
+
+func TestDetectBadVersion(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeSystem("garbage", "0")}
+	if _, err := New(r).Detect(context.Background()); err == nil {
+		t.Error("want error for unparseable version")
+	}
+}
+
+func TestGetCaches(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeSystem("15.0", "0")}
+	c := New(r)
+	ctx := context.Background()
+
+	if _, err := c.Get(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	probes := len(r.Calls())
+	if _, err := c.Get(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(r.Calls()); n != probes {
+		t.Errorf("cached Get ran %d more probes", n-probes)
+	}
+	if _, err := c.Get(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(r.Calls()); n != 2*probes {
+		t.Errorf("refresh ran %d probes, want %d", n-probes, probes)
+	}
+}
+
+func TestDetectYabai(t *testing.T) {
+	tests := []struct {
+		name     string
+		out      string
+		err      error
+		wantPath string
+	}{
+		{name: "on PATH", out: "/usr/local/bin/yabai\n", wantPath: "/usr/local/bin/yabai"},
+		{name: "Homebrew outside PATH", out: "/opt/homebrew/bin/yabai", wantPath: "/opt/homebrew/bin/yabai"},
+		{name: "not installed", err: errors.New("exit status 1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookup applescripttest.Call
+			r := &applescripttest.Runner{Respond: func(c applescripttest.Call) (string, error) {
+				if c.Name == "/bin/sh" {
+					lookup = c
+					return tt.out, tt.err
+				}
+				return fakeSystem("15.1", "0")(c)
+			}}
+			caps, err := New(r).Detect(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if caps.YabaiPath != tt.wantPath || caps.Yabai != (tt.wantPath != "") {
+				t.Errorf("Yabai, YabaiPath = %v, %q; want %q", caps.Yabai, caps.YabaiPath, tt.wantPath)
+			}
+			if len(lookup.Args) != 2 || lookup.Args[0] != "-c" || !strings.Contains(lookup.Args[1], "/opt/homebrew/bin/yabai") {
+				t.Errorf("lookup = %+v, want a shell lookup covering the Homebrew paths", lookup)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package capability
+
+import (
+	"context"
+	"fmt"
+)
+
+// ScreenRecordingSettingsURL opens System Settings at Privacy & Security >
+// Screen & System Audio Recording.
+const ScreenRecordingSettingsURL = "x-apple.systempreferences:com.apple.preference.security?Privacy_ScreenCapture"
+
+// CGPreflightScreenCaptureAccess checks the TCC grant without prompting.
+const screenRecordingPreflightScript = `ObjC.import("CoreGraphics"); $.CGPreflightScreenCaptureAccess()`
+
+// CGRequestScreenCaptureAccess shows the system prompt, but only the first
+// time an app asks; afterwards it just reports the current grant.
+const screenRecordingRequestScript = `ObjC.import("CoreGraphics"); $.CGRequestScreenCaptureAccess()`
+
+// PermissionError reports a missing TCC permission and how to grant it.
+//
+// Without Screen Recording, captures do not fail: they return images that
+// only show the wallpaper. Capture tools should call RequireScreenRecording
+// first so the user sees this error instead.
+type PermissionError struct {
+	Permission  string `json:"permission" jsonschema:"Missing permission, e.g. 'screen-recording'"`
+	SettingsURL string `json:"settingsUrl" jsonschema:"URL of the System Settings pane that grants it"`
+	Remedy      string `json:"remedy" jsonschema:"What the user needs to do"`
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("%s permission is not granted: %s", e.Permission, e.Remedy)
+}
+
+// ScreenRecordingGranted reports whether Screen Recording is granted. Unlike
+// Get it always re-checks, since the user can grant the permission while
+// the server runs.
+func (c *Client) ScreenRecordingGranted(ctx context.Context) (bool, error) {
+	out, err := c.run.RunJXA(ctx, screenRecordingPreflightScript)
+	if err != nil {
+		return false, fmt.Errorf("failed to check Screen Recording permission: %w", err)
+	}
+	granted := out == "true"
+	c.setScreenRecording(granted)
+	return granted, nil
+}
+
+// RequireScreenRecording returns a *PermissionError if Screen Recording is not
+// granted.
+func (c *Client) RequireScreenRecording(ctx context.Context) error {
+	granted, err := c.ScreenRecordingGranted(ctx)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return &PermissionError{
+			Permission:  "screen-recording",
+			SettingsURL: ScreenRecordingSettingsURL,
+			Remedy: "enable the app running this server (your terminal or MCP client) under System Settings > Privacy & Security > " +
+				"Screen & System Audio Recording, then restart it; request_screen_permission opens that pane",
+		}
+	}
+	return nil
+}
+
+// RequestScreenRecording asks macOS for Screen Recording access and, when it
+// is still not granted, opens the System Settings pane where it can be
+// enabled. settingsOpened reports whether that pane was opened.
+func (c *Client) RequestScreenRecording(ctx context.Context) (granted, settingsOpened bool, err error) {
+	out, err := c.run.RunJXA(ctx, screenRecordingRequestScript)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to request Screen Recording permission: %w", err)
+	}
+	granted = out == "true"
+	c.setScreenRecording(granted)
+	if granted {
+		return true, false, nil
+	}
+
+	if _, err := c.run.RunCommand(ctx, "open", ScreenRecordingSettingsURL); err != nil {
+		return false, false, fmt.Errorf("failed to open System Settings: %w", err)
+	}
+	return false, true, nil
+}
+
+// setScreenRecording updates the cached probe, if any, with a fresh result.
+func (c *Client) setScreenRecording(granted bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.caps != nil && c.caps.ScreenRecording != granted {
+		c.caps.ScreenRecording = granted
+		c.caps.Notes = notes(*c.caps)
+	}
+}
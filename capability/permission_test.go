@@ -0,0 +1,67 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+func TestRequireScreenRecording(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs("false")}
+	err := New(r).RequireScreenRecording(context.Background())
+
+	var perr *PermissionError
+	if !errors.As(err, &perr) {
+		t.Fatalf("err = %v, want *PermissionError", err)
+	}
+	if perr.Permission != "screen-recording" || perr.SettingsURL != ScreenRecordingSettingsURL {
+		t.Errorf("PermissionError = %+v", perr)
+	}
+
+	r = &applescripttest.Runner{Respond: applescripttest.Outputs("true")}
+	if err := New(r).RequireScreenRecording(context.Background()); err != nil {
+		t.Errorf("granted: err = %v, want nil", err)
+	}
+}
+
+func TestRequestScreenRecordingOpensSettings(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs("false", "")}
+	granted, opened, err := New(r).RequestScreenRecording(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if granted || !opened {
+		t.Errorf("granted, opened = %v, %v; want false, true", granted, opened)
+	}
+
+	calls := r.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("got %d calls, want request + open", len(calls))
+	}
+	applescripttest.Golden(t, "screen_recording_request", calls[0].Script)
+	if calls[1].Name != "open" || len(calls[1].Args) != 1 || calls[1].Args[0] != ScreenRecordingSettingsURL {
+		t.Errorf("second call = %+v, want open %s", calls[1], ScreenRecordingSettingsURL)
+	}
+}
+
+func TestRequestScreenRecordingGranted(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeSystem("15.1", "")}
+	c := New(r)
+	if _, err := c.Get(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+
+	r.Respond = applescripttest.Outputs("true")
+	granted, opened, err := c.RequestScreenRecording(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !granted || opened {
+		t.Errorf("granted, opened = %v, %v; want true, false", granted, opened)
+	}
+	if caps, _ := c.Get(context.Background(), false); !caps.ScreenRecording {
+		t.Error("cached capabilities still report Screen Recording as missing")
+	}
+}
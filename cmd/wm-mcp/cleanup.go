@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript"
+)
+
+// defaultStuckAfter is how old an osascript or system_profiler process must
+// be before cleanup_processes treats it as stuck: twice the per-call timeout,
+// so calls still inside their timeout are left alone.
+const defaultStuckAfter = 2 * applescript.DefaultTimeout
+
+// ---------- Tool 24: Report and kill leftover helper processes ----------
+
+type CleanupProcessesArgs struct {
+	OlderThanSeconds *int `json:"olderThanSeconds,omitempty" jsonschema:"Treat helper processes older than this as stuck (default 60; this server's own calls are never killed before their 30 second timeout)"`
+	KillOrphans      bool `json:"killOrphans,omitempty" jsonschema:"Also kill osascript/system_profiler processes orphaned by an earlier server (they cannot be told apart from the user's own scripts)"`
+	DryRun           bool `json:"dryRun,omitempty" jsonschema:"Only report, do not kill anything"`
+}
+
+type CleanupProcessesResult struct {
+	InFlight  []applescript.Process  `json:"inFlight" jsonschema:"Script and command calls currently running"`
+	Leftovers []applescript.Leftover `json:"leftovers" jsonschema:"Zombie, stuck and orphaned helper processes and what was done with them"`
+	Count     int                    `json:"count" jsonschema:"Number of leftover processes"`
+}
+
+func (h *handlers) CleanupProcesses(ctx context.Context, req *mcp.CallToolRequest, args CleanupProcessesArgs) (*mcp.CallToolResult, CleanupProcessesResult, error) {
+	olderThan := defaultStuckAfter
+	if args.OlderThanSeconds != nil {
+		if *args.OlderThanSeconds < 0 {
+			return nil, CleanupProcessesResult{}, fmt.Errorf("olderThanSeconds must not be negative, got %d", *args.OlderThanSeconds)
+		}
+		olderThan = time.Duration(*args.OlderThanSeconds) * time.Second
+	}
+
+	leftovers, err := h.reaper.Cleanup(ctx, olderThan, args.KillOrphans, args.DryRun)
+	if err != nil {
+		return nil, CleanupProcessesResult{}, err
+	}
+
+	counts := make(map[string]int)
+	for _, l := range leftovers {
+		counts[l.Action]++
+	}
+	text := fmt.Sprintf("Found %d leftover helper process(es): %d killed, %d reported, %d failed",
+		len(leftovers), counts[applescript.ActionKilled], counts[applescript.ActionReported], counts[applescript.ActionFailed])
+	if args.DryRun {
+		text += " (dry run)"
+	}
+
+	inFlight := h.reaper.Tracker.Running()
+	if inFlight == nil {
+		inFlight = []applescript.Process{}
+	}
+	if leftovers == nil {
+		leftovers = []applescript.Leftover{}
+	}
+	return textResult(text), CleanupProcessesResult{
+		InFlight:  inFlight,
+		Leftovers: leftovers,
+		Count:     len(leftovers),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript"
+	"github.com/bad33ndj3/mcp-macos-window-manager/capability"
+)
+
+// Degraded annotates read-only results served from the CoreGraphics window
+// list because macOS blocked System Events. It is embedded in tool results
+// and omitted from them when System Events works.
+type Degraded struct {
+	DegradedMode      bool                        `json:"degradedMode,omitempty" jsonschema:"True when System Events is blocked and this result comes from the CoreGraphics window list"`
+	Unavailable       []string                    `json:"unavailable,omitempty" jsonschema:"Capabilities that need Automation/Accessibility permission for System Events"`
+	TitlesUnavailable *capability.PermissionError `json:"titlesUnavailable,omitempty" jsonschema:"Set when window titles are empty because Screen Recording is not granted, with how to grant it"`
+}
+
+// systemEventsCapabilities is what stops working without System Events.
+var systemEventsCapabilities = []string{
+	"moving and resizing windows",
+	"keystrokes",
+	"Split View pairing",
+	"window watches",
+	"exact window indices (approximated from front-to-back order)",
+}
+
+const degradedNote = " [degraded mode: System Events is blocked, read-only data from CoreGraphics; grant Automation and Accessibility permission for full control]"
+
+// degraded returns the annotation for a result served without System Events.
+// Screen Recording is re-checked on every call, so a grant made while the
+// server runs is reflected immediately.
+func (h *handlers) degraded(ctx context.Context) Degraded {
+	d := Degraded{
+		DegradedMode:      true,
+		Unavailable:       append([]string(nil), systemEventsCapabilities...),
+		TitlesUnavailable: h.titlesHint(ctx),
+	}
+	if d.TitlesUnavailable != nil {
+		d.Unavailable = append(d.Unavailable, "window titles (CoreGraphics needs Screen Recording permission)")
+	}
+	return d
+}
+
+// explainDenied wraps a tool handler so that a System Events denial, which
+// osascript reports as a bare error code, says what happened and what still
+// works.
+func explainDenied[In, Out any](fn mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, in In) (*mcp.CallToolResult, Out, error) {
+		res, out, err := fn(ctx, req, in)
+		if applescript.IsAutomationDenied(err) {
+			err = fmt.Errorf("macOS blocked control of System Events: grant the app running this server Automation (System Events) "+
+				"and Accessibility permission under System Settings > Privacy & Security. list_all_windows, get_app_all_windows "+
+				"and get_app_window_geometry fall back to read-only CoreGraphics data in degraded mode: %w", err)
+		}
+		return res, out, err
+	}
+}
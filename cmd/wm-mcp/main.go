@@ -0,0 +1,225 @@
+// Command wm-mcp is a macOS window manager MCP server. It exposes the
+// windowmgr, display, layout and capability packages as MCP tools over stdio,
+// or over streamable HTTP with -http.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript"
+)
+
+// shutdownGrace is how long an HTTP server stopped by a signal waits for
+// open requests before closing their connections.
+const shutdownGrace = 5 * time.Second
+
+// ---------- main: MCP server over stdio or HTTP ----------
+
+func main() {
+	httpAddr := flag.String("http", "", "serve streamable HTTP on this address (e.g. localhost:8080) instead of stdio")
+	flag.Parse()
+
+	// Exec starts every call in its own process group, so a Ctrl-C or a
+	// SIGTERM sent to the server's group no longer reaches it. Cancelling
+	// the context on those signals kills calls in flight instead.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Every osascript/system_profiler call is tracked so cleanup_processes
+	// can tell calls in flight from leftovers.
+	procs := applescript.NewTracker()
+	h := newHandlers(applescript.Exec{Tracker: procs})
+	h.reaper.Tracker = procs
+
+	var opts mcp.ServerOptions
+	if *httpAddr != "" {
+		opts.KeepAlive = httpKeepAlive
+		opts.KeepAliveFailureThreshold = httpKeepAliveFailures
+	}
+	server := mcp.NewServer(&mcp.Implementation{
+		Name:    "apple-window-manager",
+		Version: "0.3.0",
+	}, &opts)
+
+	// Tool 1: move & resize
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "move_resize_app",
+		Description: "Move and resize an application's frontmost window using AppleScript on macOS.",
+	}, explainDenied(h.MoveResizeApp))
+
+	// Tool 2: get window geometry
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_app_window_geometry",
+		Description: "Get position and size of an application's frontmost window.",
+	}, explainDenied(h.GetAppWindowGeometry))
+
+	// Tool 3: get main screen / desktop bounds
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_main_screen_bounds",
+		Description: "Get the bounds of the main desktop (Finder desktop window).",
+	}, explainDenied(h.GetMainScreenBounds))
+
+	// Tool 4: list all windows from all applications
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_all_windows",
+		Description: "List all visible windows from all running applications with their positions and sizes, grouped by app or ordered by recent focus.",
+	}, explainDenied(h.ListAllWindows))
+
+	// Tool 5: get all windows for a specific application
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_app_all_windows",
+		Description: "Get all windows for a specific application (handles multi-window apps).",
+	}, explainDenied(h.GetAppAllWindows))
+
+	// Tool 6: move and resize specific window by index
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "move_resize_app_window",
+		Description: "Move and resize a specific window by index for multi-window applications.",
+	}, explainDenied(h.MoveResizeAppWindow))
+
+	// Tool 7: list all screens / displays
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_all_screens",
+		Description: "List all connected physical displays/monitors with their bounds, rotation, mirroring and built-in status, plus the arrangement graph (which display is left/right/above/below which).",
+	}, explainDenied(h.ListAllScreens))
+
+	// Tool 8: move app to specific screen with positioning presets
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "move_app_to_screen",
+		Description: "Convenience tool to move an application to a specific screen with positioning presets (center, maximize, left-half, right-half, etc.).",
+	}, explainDenied(h.MoveAppToScreen))
+
+	// Tool 9: detect macOS version and available facilities
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_capabilities",
+		Description: "Detect the macOS version and available facilities (native tiling, Stage Manager, Screen Recording permission, yabai) and explain how they affect the other tools.",
+	}, explainDenied(h.GetCapabilities))
+
+	// Tool 10: report the active Space per display
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_current_space",
+		Description: "Get the active Space (virtual desktop) index and ID for each display.",
+	}, explainDenied(h.GetCurrentSpace))
+
+	// Tool 11: resize preserving aspect ratio / anchor point
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "resize_app_window",
+		Description: "Resize an application window, optionally preserving its aspect ratio and keeping a chosen corner or the center fixed.",
+	}, explainDenied(h.ResizeAppWindow))
+
+	// Tool 12: most recently focused windows
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_recent_windows",
+		Description: "Get the last N focused windows, most recent first (CoreGraphics front-to-back order plus focus tracking).",
+	}, explainDenied(h.GetRecentWindows))
+
+	// Tool 13: send a keyboard shortcut
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "send_keystroke",
+		Description: "Send a key combination (e.g. control+command+f to exit fullscreen) to an application, optionally raising a specific window first.",
+	}, explainDenied(h.SendKeystroke))
+
+	// Tools 14-16: window watches with notifications
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "watch_for_window",
+		Description: "Watch for windows matching an app and/or title pattern to appear or disappear (e.g. a 'Zoom Meeting' window opening). Events are sent as MCP logging notifications (notifications/message, logger 'watch_for_window') once the client sets a logging level.",
+	}, explainDenied(h.WatchForWindow))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "unwatch_window",
+		Description: "Stop a watch created by watch_for_window.",
+	}, explainDenied(h.UnwatchWindow))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_window_watches",
+		Description: "List active watch_for_window subscriptions.",
+	}, explainDenied(h.ListWindowWatches))
+
+	// Tool 17: native Split View
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "pair_windows_split_view",
+		Description: "Put two windows side by side in macOS native full-screen Split View (left/right) using the Window menu tiling items. Requires English menu names.",
+	}, explainDenied(h.PairWindowsSplitView))
+
+	// Tool 18: undo presets
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "restore_window",
+		Description: "Restore a window to the frame it had before move_app_to_screen applied a preset. Calling it again re-applies the preset (toggle, like the zoom button).",
+	}, explainDenied(h.RestoreWindow))
+
+	// Tools 19-20: layout plans
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "plan_layout",
+		Description: "Preview a multi-window arrangement without moving anything. Returns the concrete operations (window, current frame, target frame, screen) in execution order; pass them verbatim to apply_layout.",
+	}, explainDenied(h.PlanLayout))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "apply_layout",
+		Description: "Execute operations returned by plan_layout, in order. Previous frames are remembered for restore_window. Warns about windows that moved since planning.",
+	}, explainDenied(h.ApplyLayout))
+
+	// Tool 21: Screen Recording permission
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "request_screen_permission",
+		Description: "Request the Screen Recording permission (needed for screenshots and CoreGraphics window titles). If it is not granted, opens the System Settings pane where it can be enabled.",
+	}, explainDenied(h.RequestScreenPermission))
+
+	// Tool 22: placement pre-flight
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "validate_placement",
+		Description: "Check a proposed window frame without moving anything: reports how much would be off-screen or under the menu bar/Dock, an unreachable title bar, spanning displays, and sizes below the app's learned minimum. Move tools run the same checks and append warnings.",
+	}, explainDenied(h.ValidatePlacement))
+
+	// Tool 23: character-cell sizing
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "resize_window_cells",
+		Description: "Size a terminal or editor window in character columns x rows instead of pixels, avoiding partial rows. Terminal and iTerm2 are sized through their own scripting; other apps (Alacritty, editors) need metrics with the cell size and padding in pixels.",
+	}, explainDenied(h.ResizeWindowCells))
+
+	// Tool 24: process maintenance
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "cleanup_processes",
+		Description: "Report osascript/system_profiler processes left behind by crashed or hung calls, including zombies with their parent PID, and kill stuck ones. Use when System Events queries get slow in a long session.",
+	}, h.CleanupProcesses)
+
+	if *httpAddr != "" {
+		// Every HTTP client gets its own session, and with it its own
+		// restore frames and watches (see handlers.client). Keepalive pings
+		// close sessions whose client is gone, so endSession still runs and
+		// their watches stop.
+		handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil)
+		srv := &http.Server{
+			Addr:        *httpAddr,
+			Handler:     handler,
+			BaseContext: func(net.Listener) context.Context { return ctx },
+		}
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+			defer cancel()
+			if err := srv.Shutdown(shutdownCtx); err != nil {
+				srv.Close()
+			}
+		}()
+		log.Printf("MCP server listening on http://%s", *httpAddr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("MCP server failed: %v", err)
+		}
+		return
+	}
+
+	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil && ctx.Err() == nil {
+		log.Fatalf("MCP server failed: %v", err)
+	}
+}
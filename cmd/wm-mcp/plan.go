@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/layout"
+	"github.com/bad33ndj3/mcp-macos-window-manager/windowmgr"
+)
+
+// ---------- Tool 19: Preview a multi-window layout ----------
+
+type PlanLayoutArgs struct {
+	Placements []layout.Placement `json:"placements" jsonschema:"Target arrangement: where each window should go"`
+}
+
+type LayoutPlan struct {
+	Operations []layout.Operation `json:"operations" jsonschema:"Operations in execution order; pass verbatim to apply_layout"`
+}
+
+func (h *handlers) PlanLayout(ctx context.Context, req *mcp.CallToolRequest, args PlanLayoutArgs) (*mcp.CallToolResult, LayoutPlan, error) {
+	if len(args.Placements) == 0 {
+		return nil, LayoutPlan{}, fmt.Errorf("placements is required")
+	}
+
+	screens, err := h.displays.List(ctx)
+	if err != nil {
+		return nil, LayoutPlan{}, fmt.Errorf("failed to get screens: %w", err)
+	}
+
+	current := make([]layout.Rect, len(args.Placements))
+	for i, p := range args.Placements {
+		idx := p.WindowIndex
+		if idx == 0 {
+			idx = 1
+		}
+		g, err := h.wm.WindowGeometry(ctx, p.AppName, idx)
+		if err != nil {
+			return nil, LayoutPlan{}, fmt.Errorf("placement %d: %w", i+1, err)
+		}
+		current[i] = layout.Rect{X: g.X, Y: g.Y, Width: g.Width, Height: g.Height}
+	}
+
+	// Without visible frames presets fill the full display.
+	frames := h.visibleFrames(ctx)
+	ops, err := layout.Plan(screens.Displays, frames, args.Placements, current)
+	if err != nil {
+		return nil, LayoutPlan{}, err
+	}
+
+	text := fmt.Sprintf("Planned %d operation(s); nothing was moved:", len(ops))
+	for _, op := range ops {
+		text += fmt.Sprintf("\n%d. '%s' window %d -> screen %d (%s) %s: (%d,%d) %dx%d -> (%d,%d) %dx%d",
+			op.Step, op.AppName, op.WindowIndex, op.ScreenIndex, op.ScreenName, op.Position,
+			op.From.X, op.From.Y, op.From.Width, op.From.Height, op.To.X, op.To.Y, op.To.Width, op.To.Height)
+	}
+	return textResult(text), LayoutPlan{Operations: ops}, nil
+}
+
+// ---------- Tool 20: Execute a layout plan ----------
+
+type ApplyLayoutArgs struct {
+	Operations []layout.Operation `json:"operations" jsonschema:"Operations returned by plan_layout"`
+}
+
+type ApplyLayoutResult struct {
+	Applied  int      `json:"applied" jsonschema:"Number of operations applied"`
+	Warnings []string `json:"warnings,omitempty" jsonschema:"Windows that moved between planning and applying, and pre-flight problems with target frames"`
+}
+
+func (h *handlers) ApplyLayout(ctx context.Context, req *mcp.CallToolRequest, args ApplyLayoutArgs) (*mcp.CallToolResult, ApplyLayoutResult, error) {
+	if len(args.Operations) == 0 {
+		return nil, ApplyLayoutResult{}, fmt.Errorf("operations is required")
+	}
+
+	var result ApplyLayoutResult
+	frames := h.visibleFrames(ctx)
+	for _, op := range args.Operations {
+		cur, err := h.wm.WindowGeometry(ctx, op.AppName, op.WindowIndex)
+		if err != nil {
+			return nil, result, fmt.Errorf("step %d (%d of %d applied): %w", op.Step, result.Applied, len(args.Operations), err)
+		}
+		if (layout.Rect{X: cur.X, Y: cur.Y, Width: cur.Width, Height: cur.Height}) != op.From {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("step %d: '%s' window %d moved since planning (now at (%d,%d) %dx%d)",
+				op.Step, op.AppName, op.WindowIndex, cur.X, cur.Y, cur.Width, cur.Height))
+		}
+		windowID, idErr := h.wm.WindowID(ctx, op.AppName, cur)
+
+		to := op.To
+		for _, w := range h.preflight(frames, op.AppName, to) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("step %d: %s", op.Step, w))
+		}
+		if err := h.wm.MoveResizeWindow(ctx, op.AppName, op.WindowIndex, to.X, to.Y, to.Width, to.Height); err != nil {
+			return nil, result, fmt.Errorf("step %d (%d of %d applied): %w", op.Step, result.Applied, len(args.Operations), err)
+		}
+		after := h.learnMinSize(ctx, op.AppName, op.WindowIndex, to)
+		result.Applied++
+
+		if idErr == nil {
+			h.client(req).restore.Save(windowmgr.SavedFrame{
+				WindowID: windowID,
+				Before:   cur,
+				After:    after,
+			})
+		}
+	}
+
+	text := fmt.Sprintf("Applied %d operation(s)", result.Applied)
+	for _, w := range result.Warnings {
+		text += "\nwarning: " + w
+	}
+	return textResult(h.withPlacementNotes(ctx, text)), result, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+	"github.com/bad33ndj3/mcp-macos-window-manager/layout"
+)
+
+func TestPlanLayoutDoesNotMove(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeMac}
+	h := newHandlers(r)
+
+	_, plan, err := h.PlanLayout(context.Background(), nil, PlanLayoutArgs{Placements: []layout.Placement{
+		{AppName: "Safari", Position: "left-half"},
+		{AppName: "Terminal", Position: "right-half"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Operations) != 2 {
+		t.Fatalf("got %d operations, want 2", len(plan.Operations))
+	}
+	if to := plan.Operations[1].To; to != (layout.Rect{X: 720, Y: 0, Width: 720, Height: 900}) {
+		t.Errorf("step 2 target = %+v, want right half of 1440x900", to)
+	}
+	for _, s := range r.Scripts() {
+		if strings.Contains(s, "set position") {
+			t.Errorf("plan_layout moved a window:\n%s", s)
+		}
+	}
+}
+
+func TestApplyLayoutRecordsRestoreFrames(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeMac}
+	h := newHandlers(r)
+	ctx := context.Background()
+
+	_, plan, err := h.PlanLayout(ctx, nil, PlanLayoutArgs{Placements: []layout.Placement{
+		{AppName: "Safari", Position: "maximize"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, got, err := h.ApplyLayout(ctx, nil, ApplyLayoutArgs{Operations: plan.Operations})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Applied != 1 || len(got.Warnings) != 0 {
+		t.Errorf("result = %+v, want 1 applied without warnings", got)
+	}
+
+	var move string
+	for _, s := range r.Scripts() {
+		if strings.Contains(s, "set position") {
+			move = s
+		}
+	}
+	applescripttest.Golden(t, "apply_layout_maximize", move)
+
+	if f, ok := h.client(nil).restore.Get(42); !ok || f.Before.Width != 1600 {
+		t.Errorf("restore frame = %+v, %v; want the pre-layout frame", f, ok)
+	}
+}
+
+func TestApplyLayoutWarnsOnDrift(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: fakeMac})
+	_, got, err := h.ApplyLayout(context.Background(), nil, ApplyLayoutArgs{Operations: []layout.Operation{{
+		Step: 1, AppName: "Safari", WindowIndex: 1, Position: "maximize",
+		From: layout.Rect{X: 0, Y: 0, Width: 10, Height: 10},
+		To:   layout.Rect{X: 0, Y: 0, Width: 1440, Height: 900},
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Warnings) != 1 || !strings.Contains(got.Warnings[0], "moved since planning") {
+		t.Errorf("warnings = %q, want one drift warning", got.Warnings)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/windowmgr"
+)
+
+// HTTP sessions are pinged every httpKeepAlive and closed after
+// httpKeepAliveFailures missed pings in a row. Without it a client that goes
+// away without a DELETE never ends its session, and its state and watches
+// live for the life of the server. An idle timeout would not do: a client
+// that only waits for watch notifications sends no requests.
+const (
+	httpKeepAlive         = time.Minute
+	httpKeepAliveFailures = 3
+)
+
+// clientState is what one MCP client session owns. Each client gets its own
+// restore frames and watches, so concurrent HTTP clients cannot undo or
+// cancel each other's work.
+type clientState struct {
+	restore *windowmgr.RestoreStore
+	watches watchRegistry
+}
+
+func newClientState() *clientState {
+	return &clientState{restore: windowmgr.NewRestoreStore()}
+}
+
+// client returns the state of the session that sent req. Requests without a
+// session (handlers called directly, as in tests) share the "" session,
+// which is also the ID of the single stdio session.
+//
+// The first request of a session arranges for its state to be dropped, and
+// its watches stopped, when the session ends.
+func (h *handlers) client(req *mcp.CallToolRequest) *clientState {
+	var session *mcp.ServerSession
+	if req != nil {
+		session = req.Session
+	}
+	id := ""
+	if session != nil {
+		id = session.ID()
+	}
+
+	st, created := h.sessions.Get(id)
+	if created && session != nil {
+		go func() {
+			_ = session.Wait()
+			h.endSession(id)
+		}()
+	}
+	return st
+}
+
+// endSession drops the state of session id.
+func (h *handlers) endSession(id string) {
+	if st, ok := h.sessions.Delete(id); ok {
+		st.watches.stopAll()
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+	"github.com/bad33ndj3/mcp-macos-window-manager/windowmgr"
+)
+
+func TestClientStateIsolated(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: fakeMac})
+
+	a, _ := h.sessions.Get("a")
+	b, _ := h.sessions.Get("b")
+	a.restore.Save(windowmgr.SavedFrame{WindowID: 42})
+	if _, ok := b.restore.Get(42); ok {
+		t.Error("session b sees session a's restore frame")
+	}
+}
+
+func TestEndSessionStopsWatches(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: fakeMac})
+	if _, _, err := h.WatchForWindow(context.Background(), nil, WatchForWindowArgs{AppName: "zoom"}); err != nil {
+		t.Fatal(err)
+	}
+	st := h.client(nil)
+	watches := st.watches.list()
+	if len(watches) != 1 {
+		t.Fatalf("got %d watches, want 1", len(watches))
+	}
+
+	h.endSession("")
+	if n := len(st.watches.list()); n != 0 {
+		t.Errorf("%d watches still registered after the session ended", n)
+	}
+	if len(h.sessions.IDs()) != 0 {
+		t.Error("session state was not dropped")
+	}
+
+	// A watch_for_window that fetched st just before the session ended must
+	// not leave a watch nobody can stop.
+	if err := st.watches.add(&windowWatch{cancel: func() {}}); err == nil {
+		t.Error("add after the session ended: want error")
+	}
+}
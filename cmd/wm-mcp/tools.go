@@ -0,0 +1,749 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript"
+	"github.com/bad33ndj3/mcp-macos-window-manager/capability"
+	"github.com/bad33ndj3/mcp-macos-window-manager/display"
+	"github.com/bad33ndj3/mcp-macos-window-manager/layout"
+	"github.com/bad33ndj3/mcp-macos-window-manager/state"
+	"github.com/bad33ndj3/mcp-macos-window-manager/windowmgr"
+)
+
+// handlers holds the clients the MCP tool handlers run against. Every process
+// execution goes through the injected runner, so handlers can be tested with
+// applescripttest.Runner.
+//
+// Facts about the desktop (focus history, window-list polling, learned
+// minimum sizes) are shared; what a client did (restore frames, watches) is
+// kept per session.
+type handlers struct {
+	wm       *windowmgr.Client
+	displays *display.Client
+	caps     *capability.Client
+	focus    *windowmgr.FocusTracker
+	watcher  *windowmgr.WatchPoller
+	minSizes *windowmgr.MinSizes
+	sessions *state.Sessions[clientState]
+	reaper   *applescript.Reaper
+}
+
+func newHandlers(r applescript.Runner) *handlers {
+	wm := windowmgr.New(r)
+	return &handlers{
+		wm:       wm,
+		displays: display.New(r),
+		caps:     capability.New(r),
+		focus:    windowmgr.NewFocusTracker(wm),
+		watcher:  windowmgr.NewWatchPoller(wm),
+		minSizes: windowmgr.NewMinSizes(),
+		sessions: state.New(newClientState),
+		reaper:   applescript.NewReaper(r),
+	}
+}
+
+// withPlacementNotes appends capability placement notes (e.g. Stage Manager
+// enabled) to a tool's result text.
+func (h *handlers) withPlacementNotes(ctx context.Context, text string) string {
+	notes := h.caps.PlacementNotes(ctx)
+	if len(notes) == 0 {
+		return text
+	}
+	return text + " (note: " + strings.Join(notes, "; ") + ")"
+}
+
+func textResult(text string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text},
+		},
+	}
+}
+
+// ---------- Tool 1: Move + resize app window ----------
+
+type MoveResizeArgs struct {
+	// Example: "Google Chrome", "Visual Studio Code", "Safari"
+	AppName string `json:"appName" jsonschema:"Name of the application, e.g. 'Google Chrome'"`
+	// Pixel coordinates relative to the top-left of the main display / desktop space.
+	X int `json:"x" jsonschema:"X position in pixels"`
+	Y int `json:"y" jsonschema:"Y position in pixels"`
+	// Window size in pixels.
+	Width  int `json:"width" jsonschema:"Window width in pixels"`
+	Height int `json:"height" jsonschema:"Window height in pixels"`
+}
+
+func (h *handlers) MoveResizeApp(ctx context.Context, req *mcp.CallToolRequest, args MoveResizeArgs) (*mcp.CallToolResult, any, error) {
+	r := layout.Rect{X: args.X, Y: args.Y, Width: args.Width, Height: args.Height}
+	warnings := h.preflight(h.visibleFrames(ctx), args.AppName, r)
+
+	if err := h.wm.MoveResize(ctx, args.AppName, args.X, args.Y, args.Width, args.Height); err != nil {
+		return nil, nil, err
+	}
+	h.learnMinSize(ctx, args.AppName, 1, r)
+
+	text := h.withPlacementNotes(ctx, fmt.Sprintf("Moved '%s' to (%d,%d) with size %dx%d", args.AppName, args.X, args.Y, args.Width, args.Height))
+	return textResult(withWarnings(text, warnings)), nil, nil
+}
+
+// ---------- Tool 2: Get current window geometry for an app ----------
+
+type GetWindowArgs struct {
+	AppName string `json:"appName" jsonschema:"Name of the application, e.g. 'Google Chrome'"`
+}
+
+type GeometryResult struct {
+	windowmgr.Geometry
+	Degraded
+}
+
+func (h *handlers) GetAppWindowGeometry(ctx context.Context, req *mcp.CallToolRequest, args GetWindowArgs) (*mcp.CallToolResult, GeometryResult, error) {
+	var result GeometryResult
+	geom, err := h.wm.FrontGeometry(ctx, args.AppName)
+	if applescript.IsAutomationDenied(err) {
+		result.Degraded = h.degraded(ctx)
+		geom, err = h.wm.FrontGeometryFromCG(ctx, args.AppName)
+	}
+	if err != nil {
+		return nil, GeometryResult{}, err
+	}
+	result.Geometry = geom
+
+	text := fmt.Sprintf("Window '%s': pos=(%d,%d) size=%dx%d", geom.AppName, geom.X, geom.Y, geom.Width, geom.Height)
+	if result.DegradedMode {
+		text += degradedNote
+	}
+	return textResult(text), result, nil
+}
+
+// ---------- Tool 3: Get main desktop (screen) bounds ----------
+
+func (h *handlers) GetMainScreenBounds(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, display.Bounds, error) {
+	sb, err := h.displays.MainBounds(ctx)
+	if err != nil {
+		return nil, display.Bounds{}, err
+	}
+
+	text := fmt.Sprintf("Main desktop bounds: left=%d top=%d right=%d bottom=%d width=%d height=%d",
+		sb.Left, sb.Top, sb.Right, sb.Bottom, sb.Width, sb.Height)
+	return textResult(text), sb, nil
+}
+
+// ---------- Tool 4: List all windows from all apps ----------
+
+type ListAllWindowsArgs struct {
+	Order string `json:"order,omitempty" jsonschema:"Ordering: 'app' (default, grouped by application) or 'recent' (most recently focused first)"`
+}
+
+type ListAllWindowsResult struct {
+	Windows []windowmgr.WindowInfo `json:"windows" jsonschema:"List of all visible windows"`
+	Count   int                    `json:"count" jsonschema:"Total number of windows"`
+	Degraded
+}
+
+func (h *handlers) ListAllWindows(ctx context.Context, req *mcp.CallToolRequest, args ListAllWindowsArgs) (*mcp.CallToolResult, ListAllWindowsResult, error) {
+	if args.Order != "" && args.Order != "app" && args.Order != "recent" {
+		return nil, ListAllWindowsResult{}, fmt.Errorf("invalid order: %q (valid: app, recent)", args.Order)
+	}
+
+	var degraded Degraded
+	windows, err := h.wm.ListAll(ctx)
+	if applescript.IsAutomationDenied(err) {
+		degraded = h.degraded(ctx)
+		windows, err = h.wm.ListAllFromCG(ctx)
+	}
+	if err != nil {
+		return nil, ListAllWindowsResult{}, err
+	}
+
+	if args.Order == "recent" {
+		recent, err := h.focus.Recent(ctx)
+		if err != nil {
+			return nil, ListAllWindowsResult{}, fmt.Errorf("failed to get focus order: %w", err)
+		}
+		windowmgr.SortByRecency(windows, recent)
+	}
+
+	text := fmt.Sprintf("Found %d windows across all applications", len(windows))
+	if degraded.DegradedMode {
+		text += degradedNote
+	}
+	return textResult(text), ListAllWindowsResult{
+		Windows:  windows,
+		Count:    len(windows),
+		Degraded: degraded,
+	}, nil
+}
+
+// ---------- Tool 5: Get all windows for a specific app ----------
+
+type GetAppAllWindowsResult struct {
+	AppName string                    `json:"appName" jsonschema:"Application name"`
+	Windows []windowmgr.AppWindowInfo `json:"windows" jsonschema:"List of all windows for this app"`
+	Count   int                       `json:"count" jsonschema:"Total number of windows"`
+	Degraded
+}
+
+func (h *handlers) GetAppAllWindows(ctx context.Context, req *mcp.CallToolRequest, args GetWindowArgs) (*mcp.CallToolResult, GetAppAllWindowsResult, error) {
+	var degraded Degraded
+	windows, err := h.wm.AppWindows(ctx, args.AppName)
+	if applescript.IsAutomationDenied(err) {
+		degraded = h.degraded(ctx)
+		windows, err = h.wm.AppWindowsFromCG(ctx, args.AppName)
+	}
+	if err != nil {
+		return nil, GetAppAllWindowsResult{}, err
+	}
+
+	text := fmt.Sprintf("Application '%s' has %d window(s)", args.AppName, len(windows))
+	if degraded.DegradedMode {
+		text += degradedNote
+	}
+	return textResult(text), GetAppAllWindowsResult{
+		AppName:  args.AppName,
+		Windows:  windows,
+		Count:    len(windows),
+		Degraded: degraded,
+	}, nil
+}
+
+// ---------- Tool 6: Move + resize specific app window by index ----------
+
+type MoveResizeWindowArgs struct {
+	AppName     string `json:"appName" jsonschema:"Name of the application"`
+	WindowIndex int    `json:"windowIndex" jsonschema:"Window index (1-based, 1 = frontmost window)"`
+	X           int    `json:"x" jsonschema:"X position in pixels"`
+	Y           int    `json:"y" jsonschema:"Y position in pixels"`
+	Width       int    `json:"width" jsonschema:"Window width in pixels"`
+	Height      int    `json:"height" jsonschema:"Window height in pixels"`
+}
+
+func (h *handlers) MoveResizeAppWindow(ctx context.Context, req *mcp.CallToolRequest, args MoveResizeWindowArgs) (*mcp.CallToolResult, any, error) {
+	r := layout.Rect{X: args.X, Y: args.Y, Width: args.Width, Height: args.Height}
+	warnings := h.preflight(h.visibleFrames(ctx), args.AppName, r)
+
+	if err := h.wm.MoveResizeWindow(ctx, args.AppName, args.WindowIndex, args.X, args.Y, args.Width, args.Height); err != nil {
+		return nil, nil, err
+	}
+	h.learnMinSize(ctx, args.AppName, args.WindowIndex, r)
+
+	text := h.withPlacementNotes(ctx, fmt.Sprintf("Moved '%s' window %d to (%d,%d) with size %dx%d", args.AppName, args.WindowIndex, args.X, args.Y, args.Width, args.Height))
+	return textResult(withWarnings(text, warnings)), nil, nil
+}
+
+// ---------- Tool 7: List all screens / displays ----------
+
+type ListAllScreensResult struct {
+	Displays    []display.Info      `json:"displays" jsonschema:"List of all connected displays"`
+	Count       int                 `json:"count" jsonschema:"Total number of displays"`
+	TotalWidth  int                 `json:"totalWidth" jsonschema:"Total virtual desktop width"`
+	TotalHeight int                 `json:"totalHeight" jsonschema:"Total virtual desktop height"`
+	Arrangement []display.Adjacency `json:"arrangement,omitempty" jsonschema:"Which display touches which on what side"`
+	Source      string              `json:"source" jsonschema:"Where the layout came from: 'coregraphics' (exact), 'system_profiler' (guessed left-to-right) or 'desktop' (single fallback display)"`
+	Mirrors     []display.Mirror    `json:"mirrors,omitempty" jsonschema:"Displays that mirror one of displays, with the displayId they copy"`
+}
+
+func (h *handlers) ListAllScreens(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, ListAllScreensResult, error) {
+	screens, err := h.displays.List(ctx)
+	if err != nil {
+		return nil, ListAllScreensResult{}, err
+	}
+
+	text := fmt.Sprintf("Found %d display(s), total virtual desktop: %dx%d", len(screens.Displays), screens.Desktop.Width, screens.Desktop.Height)
+	if screens.Fallback {
+		text = fmt.Sprintf("Found 1 display (fallback): %dx%d", screens.Desktop.Width, screens.Desktop.Height)
+	}
+	if screens.Source == display.SourceSystemProfiler {
+		text += " (positions guessed from system_profiler)"
+	}
+	for _, a := range screens.Arrangement {
+		text += fmt.Sprintf("\n%s (%d) is %s %s (%d)",
+			screens.Displays[a.Neighbor].Name, a.Neighbor, sidePhrase(a.Side), screens.Displays[a.Display].Name, a.Display)
+	}
+	for _, m := range screens.Mirrors {
+		text += fmt.Sprintf("\nDisplay %d mirrors display %d", m.DisplayID, m.MirrorOf)
+	}
+	return textResult(text), ListAllScreensResult{
+		Displays:    screens.Displays,
+		Count:       len(screens.Displays),
+		TotalWidth:  screens.Desktop.Width,
+		TotalHeight: screens.Desktop.Height,
+		Arrangement: screens.Arrangement,
+		Source:      screens.Source,
+		Mirrors:     screens.Mirrors,
+	}, nil
+}
+
+// sidePhrase turns an Adjacency side into "<neighbor> is ... <display>" prose.
+func sidePhrase(side string) string {
+	switch side {
+	case display.SideLeft:
+		return "left of"
+	case display.SideRight:
+		return "right of"
+	}
+	return side
+}
+
+// ---------- Tool 8: Move app to specific screen with presets ----------
+
+type MoveAppToScreenArgs struct {
+	AppName     string `json:"appName" jsonschema:"Name of the application"`
+	ScreenIndex int    `json:"screenIndex" jsonschema:"Target screen index (0 = main display)"`
+	Position    string `json:"position" jsonschema:"Positioning preset: 'center', 'maximize', 'left-half', 'right-half', 'top-half', 'bottom-half', or 'custom'"`
+	// For custom positioning:
+	XOffset *int `json:"xOffset,omitempty" jsonschema:"X offset from screen left (pixels, for custom position)"`
+	YOffset *int `json:"yOffset,omitempty" jsonschema:"Y offset from screen top (pixels, for custom position)"`
+	Width   *int `json:"width,omitempty" jsonschema:"Window width (pixels, for custom position)"`
+	Height  *int `json:"height,omitempty" jsonschema:"Window height (pixels, for custom position)"`
+}
+
+func (h *handlers) MoveAppToScreen(ctx context.Context, req *mcp.CallToolRequest, args MoveAppToScreenArgs) (*mcp.CallToolResult, any, error) {
+	if args.AppName == "" {
+		return nil, nil, fmt.Errorf("appName is required")
+	}
+	if args.Position == "" {
+		return nil, nil, fmt.Errorf("position is required")
+	}
+
+	// Get all screens
+	screens, err := h.displays.List(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get screens: %w", err)
+	}
+
+	// Validate screen index
+	if args.ScreenIndex < 0 || args.ScreenIndex >= len(screens.Displays) {
+		return nil, nil, fmt.Errorf("invalid screen index %d (available: 0-%d)", args.ScreenIndex, len(screens.Displays)-1)
+	}
+
+	targetScreen := screens.Displays[args.ScreenIndex]
+
+	// Presets fill the visible frame (below the menu bar, beside the Dock);
+	// custom offsets are relative to the full display. Without visible
+	// frames presets fill the full display.
+	frames := h.visibleFrames(ctx)
+	area := targetScreen
+	if args.Position != "custom" {
+		area = layout.VisibleArea(targetScreen, frames)
+	}
+	x, y, width, height, err := layout.CalculateBounds(area, args.Position, args.XOffset, args.YOffset, args.Width, args.Height)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Identify the window before moving it so restore_window can undo the
+	// preset. Presets still apply to windows that cannot be identified.
+	before, err := h.wm.FrontGeometry(ctx, args.AppName)
+	if err != nil {
+		return nil, nil, err
+	}
+	windowID, idErr := h.wm.WindowID(ctx, args.AppName, before)
+
+	r := layout.Rect{X: x, Y: y, Width: width, Height: height}
+	warnings := h.preflight(frames, args.AppName, r)
+
+	if err := h.wm.MoveResize(ctx, args.AppName, x, y, width, height); err != nil {
+		return nil, nil, err
+	}
+	after := h.learnMinSize(ctx, args.AppName, 1, r)
+
+	if idErr == nil {
+		h.client(req).restore.Save(windowmgr.SavedFrame{
+			WindowID: windowID,
+			Before:   before,
+			After:    after,
+		})
+	}
+
+	text := h.withPlacementNotes(ctx, fmt.Sprintf("Moved '%s' to screen %d (%s) at position '%s': (%d,%d) %dx%d",
+		args.AppName, args.ScreenIndex, targetScreen.Name, args.Position, x, y, width, height))
+	return textResult(withWarnings(text, warnings)), nil, nil
+}
+
+// ---------- Tool 9: Detect macOS version and available facilities ----------
+
+type GetCapabilitiesArgs struct {
+	Refresh bool `json:"refresh,omitempty" jsonschema:"Re-probe the system instead of returning cached results"`
+}
+
+func (h *handlers) GetCapabilities(ctx context.Context, req *mcp.CallToolRequest, args GetCapabilitiesArgs) (*mcp.CallToolResult, capability.Capabilities, error) {
+	caps, err := h.caps.Get(ctx, args.Refresh)
+	if err != nil {
+		return nil, capability.Capabilities{}, err
+	}
+
+	name := caps.MacOSName
+	if name == "" {
+		name = "unknown"
+	}
+	text := fmt.Sprintf("macOS %s (%s): nativeTiling=%t stageManager=%t screenRecording=%t yabai=%t",
+		caps.MacOSVersion, name, caps.NativeTiling, caps.StageManager, caps.ScreenRecording, caps.Yabai)
+	return textResult(text), caps, nil
+}
+
+// ---------- Tool 10: Report the active Space per display ----------
+
+type GetCurrentSpaceResult struct {
+	Spaces []display.Space `json:"spaces" jsonschema:"Active Space for each display"`
+	Count  int             `json:"count" jsonschema:"Number of displays reported"`
+}
+
+func (h *handlers) GetCurrentSpace(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, GetCurrentSpaceResult, error) {
+	spaces, err := h.displays.CurrentSpaces(ctx)
+	if err != nil {
+		return nil, GetCurrentSpaceResult{}, err
+	}
+
+	var parts []string
+	for _, sp := range spaces {
+		parts = append(parts, fmt.Sprintf("%s: Space %d of %d (id %d)", sp.DisplayID, sp.SpaceIndex, sp.SpaceCount, sp.SpaceID))
+	}
+	text := "Current Space: " + strings.Join(parts, "; ")
+	return textResult(text), GetCurrentSpaceResult{
+		Spaces: spaces,
+		Count:  len(spaces),
+	}, nil
+}
+
+// ---------- Tool 11: Resize with aspect ratio and anchor ----------
+
+type ResizeAppWindowArgs struct {
+	AppName             string `json:"appName" jsonschema:"Name of the application"`
+	WindowIndex         int    `json:"windowIndex,omitempty" jsonschema:"Window index (1-based, 1 = frontmost window; defaults to 1)"`
+	Width               int    `json:"width,omitempty" jsonschema:"New width in pixels (may be omitted when preserveAspectRatio is set and height is given)"`
+	Height              int    `json:"height,omitempty" jsonschema:"New height in pixels (may be omitted when preserveAspectRatio is set and width is given)"`
+	Anchor              string `json:"anchor,omitempty" jsonschema:"Point that stays fixed: 'top-left' (default), 'top-right', 'bottom-left', 'bottom-right', or 'center'"`
+	PreserveAspectRatio bool   `json:"preserveAspectRatio,omitempty" jsonschema:"Keep the window's current aspect ratio, fitting inside width x height"`
+}
+
+type ResizeResult struct {
+	AppName     string `json:"appName" jsonschema:"Application name"`
+	WindowIndex int    `json:"windowIndex" jsonschema:"Window index that was resized"`
+	X           int    `json:"x" jsonschema:"New X position in pixels"`
+	Y           int    `json:"y" jsonschema:"New Y position in pixels"`
+	Width       int    `json:"width" jsonschema:"New width in pixels"`
+	Height      int    `json:"height" jsonschema:"New height in pixels"`
+}
+
+func (h *handlers) ResizeAppWindow(ctx context.Context, req *mcp.CallToolRequest, args ResizeAppWindowArgs) (*mcp.CallToolResult, ResizeResult, error) {
+	if args.WindowIndex == 0 {
+		args.WindowIndex = 1
+	}
+
+	cur, err := h.wm.WindowGeometry(ctx, args.AppName, args.WindowIndex)
+	if err != nil {
+		return nil, ResizeResult{}, err
+	}
+
+	nx, ny, nw, nh, err := layout.ResizeWithAnchor(cur.X, cur.Y, cur.Width, cur.Height, args.Width, args.Height, args.Anchor, args.PreserveAspectRatio)
+	if err != nil {
+		return nil, ResizeResult{}, err
+	}
+
+	r := layout.Rect{X: nx, Y: ny, Width: nw, Height: nh}
+	warnings := h.preflight(h.visibleFrames(ctx), args.AppName, r)
+
+	if err := h.wm.MoveResizeWindow(ctx, args.AppName, args.WindowIndex, nx, ny, nw, nh); err != nil {
+		return nil, ResizeResult{}, err
+	}
+	h.learnMinSize(ctx, args.AppName, args.WindowIndex, r)
+
+	anchor := args.Anchor
+	if anchor == "" {
+		anchor = "top-left"
+	}
+	text := h.withPlacementNotes(ctx, fmt.Sprintf("Resized '%s' window %d from %dx%d to %dx%d anchored at %s: now at (%d,%d)",
+		args.AppName, args.WindowIndex, cur.Width, cur.Height, nw, nh, anchor, nx, ny))
+	return textResult(withWarnings(text, warnings)), ResizeResult{
+		AppName:     args.AppName,
+		WindowIndex: args.WindowIndex,
+		X:           nx,
+		Y:           ny,
+		Width:       nw,
+		Height:      nh,
+	}, nil
+}
+
+// ---------- Tool 12: Recently focused windows ----------
+
+type GetRecentWindowsArgs struct {
+	Limit int `json:"limit,omitempty" jsonschema:"Maximum number of windows to return (default 10)"`
+}
+
+type GetRecentWindowsResult struct {
+	Windows           []windowmgr.RecentWindow    `json:"windows" jsonschema:"Windows ordered by most recent focus first"`
+	Count             int                         `json:"count" jsonschema:"Number of windows returned"`
+	TitlesUnavailable *capability.PermissionError `json:"titlesUnavailable,omitempty" jsonschema:"Set when window titles are empty because Screen Recording is not granted, with how to grant it"`
+}
+
+func (h *handlers) GetRecentWindows(ctx context.Context, req *mcp.CallToolRequest, args GetRecentWindowsArgs) (*mcp.CallToolResult, GetRecentWindowsResult, error) {
+	if args.Limit < 0 {
+		return nil, GetRecentWindowsResult{}, fmt.Errorf("limit must be >= 0")
+	}
+	limit := args.Limit
+	if limit == 0 {
+		limit = 10
+	}
+
+	windows, err := h.focus.Recent(ctx)
+	if err != nil {
+		return nil, GetRecentWindowsResult{}, err
+	}
+	if len(windows) > limit {
+		windows = windows[:limit]
+	}
+
+	var names []string
+	for _, w := range windows {
+		names = append(names, w.AppName)
+	}
+	text := fmt.Sprintf("%d most recently used window(s): %s", len(windows), strings.Join(names, ", "))
+	hint := h.titlesHint(ctx)
+	if hint != nil {
+		text += titlesNote
+	}
+	return textResult(text), GetRecentWindowsResult{
+		Windows:           windows,
+		Count:             len(windows),
+		TitlesUnavailable: hint,
+	}, nil
+}
+
+// ---------- Tool 13: Send a keyboard shortcut to an app ----------
+
+type SendKeystrokeArgs struct {
+	AppName     string   `json:"appName" jsonschema:"Name of the application to receive the keystroke"`
+	Key         string   `json:"key" jsonschema:"A single character (e.g. 'f', '[') or a named key: return, enter, tab, space, delete, forward-delete, escape, home, end, page-up, page-down, left, right, up, down, f1-f12"`
+	Modifiers   []string `json:"modifiers,omitempty" jsonschema:"Modifier keys to hold: command, option, control, shift"`
+	WindowIndex int      `json:"windowIndex,omitempty" jsonschema:"Window to raise before sending (1-based); omit to send to the app's current window"`
+}
+
+func (h *handlers) SendKeystroke(ctx context.Context, req *mcp.CallToolRequest, args SendKeystrokeArgs) (*mcp.CallToolResult, any, error) {
+	if err := h.wm.SendKeystroke(ctx, args.AppName, args.Key, args.Modifiers, args.WindowIndex); err != nil {
+		return nil, nil, err
+	}
+
+	combo := args.Key
+	if len(args.Modifiers) > 0 {
+		combo = strings.Join(args.Modifiers, "+") + "+" + args.Key
+	}
+	target := fmt.Sprintf("'%s'", args.AppName)
+	if args.WindowIndex > 0 {
+		target = fmt.Sprintf("'%s' window %d", args.AppName, args.WindowIndex)
+	}
+	return textResult(fmt.Sprintf("Sent %s to %s", combo, target)), nil, nil
+}
+
+// ---------- Tool 17: Pair two windows in native Split View ----------
+
+type PairWindowsSplitViewArgs struct {
+	Left  windowmgr.WindowRef `json:"left" jsonschema:"Window for the left half"`
+	Right windowmgr.WindowRef `json:"right" jsonschema:"Window for the right half"`
+}
+
+func (h *handlers) PairWindowsSplitView(ctx context.Context, req *mcp.CallToolRequest, args PairWindowsSplitViewArgs) (*mcp.CallToolResult, any, error) {
+	caps, err := h.caps.Get(ctx, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := h.wm.SplitView(ctx, args.Left, args.Right, caps.NativeTiling); err != nil {
+		return nil, nil, err
+	}
+
+	text := fmt.Sprintf("Paired '%s' (left) and '%s' (right) in Split View", args.Left.AppName, args.Right.AppName)
+	return textResult(text), nil, nil
+}
+
+// ---------- Tool 18: Restore the frame from before a preset ----------
+
+type RestoreWindowArgs struct {
+	AppName     string `json:"appName" jsonschema:"Name of the application"`
+	WindowIndex int    `json:"windowIndex,omitempty" jsonschema:"Window index (1-based, 1 = frontmost window; defaults to 1)"`
+}
+
+type RestoreResult struct {
+	AppName     string `json:"appName" jsonschema:"Application name"`
+	WindowIndex int    `json:"windowIndex" jsonschema:"Window index that was restored"`
+	WindowID    int    `json:"windowId" jsonschema:"CoreGraphics window number the frame was remembered under"`
+	X           int    `json:"x" jsonschema:"Restored X position in pixels"`
+	Y           int    `json:"y" jsonschema:"Restored Y position in pixels"`
+	Width       int    `json:"width" jsonschema:"Restored width in pixels"`
+	Height      int    `json:"height" jsonschema:"Restored height in pixels"`
+}
+
+func (h *handlers) RestoreWindow(ctx context.Context, req *mcp.CallToolRequest, args RestoreWindowArgs) (*mcp.CallToolResult, RestoreResult, error) {
+	if args.WindowIndex == 0 {
+		args.WindowIndex = 1
+	}
+
+	cur, err := h.wm.WindowGeometry(ctx, args.AppName, args.WindowIndex)
+	if err != nil {
+		return nil, RestoreResult{}, err
+	}
+	windowID, err := h.wm.WindowID(ctx, args.AppName, cur)
+	if err != nil {
+		return nil, RestoreResult{}, fmt.Errorf("cannot identify '%s' window %d: %w", args.AppName, args.WindowIndex, err)
+	}
+	restore := h.client(req).restore
+	saved, ok := restore.Get(windowID)
+	if !ok {
+		return nil, RestoreResult{}, fmt.Errorf("no remembered frame for '%s' window %d; apply a preset with move_app_to_screen first", args.AppName, args.WindowIndex)
+	}
+
+	b := saved.Before
+	if err := h.wm.MoveResizeWindow(ctx, args.AppName, args.WindowIndex, b.X, b.Y, b.Width, b.Height); err != nil {
+		return nil, RestoreResult{}, err
+	}
+	// Swap the frames so the next restore_window re-applies the preset.
+	restore.Toggle(windowID)
+
+	text := h.withPlacementNotes(ctx, fmt.Sprintf("Restored '%s' window %d to (%d,%d) %dx%d",
+		args.AppName, args.WindowIndex, b.X, b.Y, b.Width, b.Height))
+	return textResult(text), RestoreResult{
+		AppName:     args.AppName,
+		WindowIndex: args.WindowIndex,
+		WindowID:    windowID,
+		X:           b.X,
+		Y:           b.Y,
+		Width:       b.Width,
+		Height:      b.Height,
+	}, nil
+}
+
+// ---------- Tool 21: Request Screen Recording permission ----------
+
+type RequestScreenPermissionResult struct {
+	Granted        bool   `json:"granted" jsonschema:"Whether Screen Recording permission is granted"`
+	SettingsOpened bool   `json:"settingsOpened" jsonschema:"Whether System Settings was opened to grant it"`
+	SettingsURL    string `json:"settingsUrl,omitempty" jsonschema:"URL of the System Settings pane"`
+}
+
+func (h *handlers) RequestScreenPermission(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, RequestScreenPermissionResult, error) {
+	granted, opened, err := h.caps.RequestScreenRecording(ctx)
+	if err != nil {
+		return nil, RequestScreenPermissionResult{}, err
+	}
+
+	result := RequestScreenPermissionResult{Granted: granted, SettingsOpened: opened}
+	text := "Screen Recording permission is granted"
+	if !granted {
+		result.SettingsURL = capability.ScreenRecordingSettingsURL
+		text = "Screen Recording permission is not granted. Opened System Settings > Privacy & Security > Screen & System Audio Recording; " +
+			"enable the app running this server (your terminal or MCP client) and restart it."
+	}
+	return textResult(text), result, nil
+}
+
+// titlesHint re-checks Screen Recording for results built from the
+// CoreGraphics window list, whose window titles are empty without it. It
+// returns nil when titles are available or the check itself fails.
+func (h *handlers) titlesHint(ctx context.Context) *capability.PermissionError {
+	var perr *capability.PermissionError
+	if errors.As(h.caps.RequireScreenRecording(ctx), &perr) {
+		return perr
+	}
+	return nil
+}
+
+// titlesNote is appended to result text when titlesHint reports a problem.
+const titlesNote = " (window titles are empty: Screen Recording permission is not granted; request_screen_permission opens the settings pane)"
+
+// ---------- Tool 23: Size terminals and editors in character cells ----------
+
+type ResizeWindowCellsArgs struct {
+	AppName     string              `json:"appName" jsonschema:"Name of the application, e.g. 'Terminal', 'iTerm2', 'Alacritty'"`
+	WindowIndex int                 `json:"windowIndex,omitempty" jsonschema:"Window index (1-based, 1 = frontmost window; defaults to 1)"`
+	Columns     int                 `json:"columns" jsonschema:"Width in character columns"`
+	Rows        int                 `json:"rows" jsonschema:"Height in character rows"`
+	X           *int                `json:"x,omitempty" jsonschema:"New X position in pixels (defaults to the current position)"`
+	Y           *int                `json:"y,omitempty" jsonschema:"New Y position in pixels (defaults to the current position)"`
+	Metrics     *layout.CellMetrics `json:"metrics,omitempty" jsonschema:"Cell size and padding in pixels; required for apps other than Terminal and iTerm2"`
+}
+
+type CellResizeResult struct {
+	AppName     string `json:"appName" jsonschema:"Application name"`
+	WindowIndex int    `json:"windowIndex" jsonschema:"Window index that was resized"`
+	Columns     int    `json:"columns" jsonschema:"Requested columns"`
+	Rows        int    `json:"rows" jsonschema:"Requested rows"`
+	Mode        string `json:"mode" jsonschema:"'native' (sized by the app's scripting) or 'metrics' (converted with the given cell metrics)"`
+	X           int    `json:"x" jsonschema:"X position in pixels"`
+	Y           int    `json:"y" jsonschema:"Y position in pixels"`
+	Width       int    `json:"width" jsonschema:"Width in pixels"`
+	Height      int    `json:"height" jsonschema:"Height in pixels"`
+}
+
+func (h *handlers) ResizeWindowCells(ctx context.Context, req *mcp.CallToolRequest, args ResizeWindowCellsArgs) (*mcp.CallToolResult, CellResizeResult, error) {
+	if args.WindowIndex == 0 {
+		args.WindowIndex = 1
+	}
+	if args.Columns <= 0 || args.Rows <= 0 {
+		return nil, CellResizeResult{}, fmt.Errorf("columns and rows must be > 0")
+	}
+
+	result := CellResizeResult{AppName: args.AppName, WindowIndex: args.WindowIndex, Columns: args.Columns, Rows: args.Rows}
+	var warnings []string
+
+	if args.Metrics == nil {
+		if !windowmgr.SupportsNativeCells(args.AppName) {
+			return nil, CellResizeResult{}, fmt.Errorf("'%s' cannot be sized in cells natively (supported: Terminal, iTerm2); pass metrics with its cellWidth, cellHeight and padding", args.AppName)
+		}
+		result.Mode = "native"
+		if err := h.wm.SetTerminalCells(ctx, args.AppName, args.WindowIndex, args.Columns, args.Rows); err != nil {
+			return nil, CellResizeResult{}, err
+		}
+		// The app picks the pixel size; read it back and only move if asked to.
+		g, err := h.wm.WindowGeometry(ctx, args.AppName, args.WindowIndex)
+		if err != nil {
+			return nil, CellResizeResult{}, err
+		}
+		result.X, result.Y, result.Width, result.Height = g.X, g.Y, g.Width, g.Height
+		if args.X != nil || args.Y != nil {
+			if args.X != nil {
+				result.X = *args.X
+			}
+			if args.Y != nil {
+				result.Y = *args.Y
+			}
+			warnings = h.preflight(h.visibleFrames(ctx), args.AppName, layout.Rect{X: result.X, Y: result.Y, Width: result.Width, Height: result.Height})
+			if err := h.wm.MoveResizeWindow(ctx, args.AppName, args.WindowIndex, result.X, result.Y, result.Width, result.Height); err != nil {
+				return nil, CellResizeResult{}, err
+			}
+		}
+	} else {
+		result.Mode = "metrics"
+		w, hgt, err := layout.CellsToPixels(args.Columns, args.Rows, *args.Metrics)
+		if err != nil {
+			return nil, CellResizeResult{}, err
+		}
+		cur, err := h.wm.WindowGeometry(ctx, args.AppName, args.WindowIndex)
+		if err != nil {
+			return nil, CellResizeResult{}, err
+		}
+		result.X, result.Y, result.Width, result.Height = cur.X, cur.Y, w, hgt
+		if args.X != nil {
+			result.X = *args.X
+		}
+		if args.Y != nil {
+			result.Y = *args.Y
+		}
+
+		r := layout.Rect{X: result.X, Y: result.Y, Width: result.Width, Height: result.Height}
+		warnings = h.preflight(h.visibleFrames(ctx), args.AppName, r)
+		if err := h.wm.MoveResizeWindow(ctx, args.AppName, args.WindowIndex, r.X, r.Y, r.Width, r.Height); err != nil {
+			return nil, CellResizeResult{}, err
+		}
+		h.learnMinSize(ctx, args.AppName, args.WindowIndex, r)
+	}
+
+	text := h.withPlacementNotes(ctx, fmt.Sprintf("Resized '%s' window %d to %dx%d cells (%s): (%d,%d) %dx%d",
+		args.AppName, args.WindowIndex, args.Columns, args.Rows, result.Mode, result.X, result.Y, result.Width, result.Height))
+	return textResult(withWarnings(text, warnings)), result, nil
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+	"github.com/bad33ndj3/mcp-macos-window-manager/layout"
+)
+
+// fakeMac answers the probes and queries the handlers issue on a single
+// 1440x900 display (menu bar and Dock hidden) running macOS 14 without Stage
+// Manager or yabai.
+func fakeMac(c applescripttest.Call) (string, error) {
+	switch {
+	case c.Name == "sw_vers":
+		return "14.5", nil
+	case c.Name == "defaults":
+		return "0", nil
+	case c.Name == "system_profiler":
+		return `{"SPDisplaysDataType": [{"spdisplays_ndrvs": [{"_name": "Built-in", "_spdisplays_resolution": "1440 x 900", "spdisplays_main": "spdisplays_yes"}]}]}`, nil
+	case strings.Contains(c.Script, "bounds of window of desktop"):
+		return "0,0,1440,900", nil
+	case strings.Contains(c.Script, "return xPos"):
+		return "100,100,1600,900", nil
+	case strings.Contains(c.Script, "CGDisplayBounds"):
+		return `[{"id": 1, "name": "Built-in", "x": 0, "y": 0, "w": 1440, "h": 900, "main": true, "builtin": true, "mirrored": false, "rotation": 0}]`, nil
+	case strings.Contains(c.Script, "visibleFrame"):
+		return `[{"frame": {"x": 0, "y": 0, "w": 1440, "h": 900}, "visible": {"x": 0, "y": 0, "w": 1440, "h": 900}}]`, nil
+	case strings.Contains(c.Script, "CGWindowListCopyWindowInfo"):
+		return `[{"kCGWindowNumber": 42, "kCGWindowOwnerName": "Safari", "kCGWindowLayer": 0, "kCGWindowAlpha": 1, "kCGWindowBounds": {"X": 100, "Y": 100, "Width": 1600, "Height": 900}}]`, nil
+	}
+	return "", nil
+}
+
+func TestMoveAppToScreen(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeMac}
+	h := newHandlers(r)
+
+	_, _, err := h.MoveAppToScreen(context.Background(), nil, MoveAppToScreenArgs{
+		AppName:  "Safari",
+		Position: "right-half",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var move string
+	for _, s := range r.Scripts() {
+		if strings.Contains(s, "set position") {
+			move = s
+		}
+	}
+	applescripttest.Golden(t, "move_app_to_screen_right_half", move)
+}
+
+func TestMoveAppToScreenRejectsBadIndex(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: fakeMac})
+	_, _, err := h.MoveAppToScreen(context.Background(), nil, MoveAppToScreenArgs{
+		AppName:     "Safari",
+		ScreenIndex: 3,
+		Position:    "maximize",
+	})
+	if err == nil || !strings.Contains(err.Error(), "invalid screen index 3") {
+		t.Errorf("err = %v, want invalid screen index", err)
+	}
+}
+
+var (
+	setSizeRE     = regexp.MustCompile(`set size to \{(-?\d+), (-?\d+)\}`)
+	setPositionRE = regexp.MustCompile(`set position to \{(-?\d+), (-?\d+)\}`)
+)
+
+// movableWindow is fakeMac with one Safari window (CG number 42) that really
+// moves, so frames read back after a move show where it ended up. Like real
+// apps, it refuses to get narrower than minWidth.
+type movableWindow struct {
+	mu       sync.Mutex
+	frame    layout.Rect
+	minWidth int
+}
+
+func newMovableWindow(minWidth int) *movableWindow {
+	return &movableWindow{frame: layout.Rect{X: 100, Y: 100, Width: 1600, Height: 900}, minWidth: minWidth}
+}
+
+func (m *movableWindow) respond(c applescripttest.Call) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	moved := false
+	if s := setSizeRE.FindStringSubmatch(c.Script); s != nil {
+		m.frame.Width, m.frame.Height = max(atoi(s[1]), m.minWidth), atoi(s[2])
+		moved = true
+	}
+	if p := setPositionRE.FindStringSubmatch(c.Script); p != nil {
+		m.frame.X, m.frame.Y = atoi(p[1]), atoi(p[2])
+		moved = true
+	}
+	f := m.frame
+	switch {
+	case moved:
+		return "", nil
+	case strings.Contains(c.Script, "return xPos"):
+		return fmt.Sprintf("%d,%d,%d,%d", f.X, f.Y, f.Width, f.Height), nil
+	case strings.Contains(c.Script, "CGWindowListCopyWindowInfo"):
+		return fmt.Sprintf(`[{"kCGWindowNumber": 42, "kCGWindowOwnerName": "Safari", "kCGWindowLayer": 0, "kCGWindowAlpha": 1, "kCGWindowBounds": {"X": %d, "Y": %d, "Width": %d, "Height": %d}}]`,
+			f.X, f.Y, f.Width, f.Height), nil
+	}
+	return fakeMac(c)
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func TestRestoreWindowToggles(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: newMovableWindow(0).respond})
+	ctx := context.Background()
+
+	if _, _, err := h.RestoreWindow(ctx, nil, RestoreWindowArgs{AppName: "Safari"}); err == nil {
+		t.Fatal("restore before any preset: want error")
+	}
+
+	if _, _, err := h.MoveAppToScreen(ctx, nil, MoveAppToScreenArgs{AppName: "Safari", Position: "left-half"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, got, err := h.RestoreWindow(ctx, nil, RestoreWindowArgs{AppName: "Safari"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.WindowID != 42 || got.X != 100 || got.Y != 100 || got.Width != 1600 || got.Height != 900 {
+		t.Errorf("restore = %+v, want window 42 back at (100,100) 1600x900", got)
+	}
+
+	_, got, err = h.RestoreWindow(ctx, nil, RestoreWindowArgs{AppName: "Safari"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.X != 0 || got.Y != 0 || got.Width != 720 || got.Height != 900 {
+		t.Errorf("second restore = %+v, want preset frame (0,0) 720x900", got)
+	}
+}
+
+func TestRestoreWindowAfterClampedChainedPresets(t *testing.T) {
+	// Safari will not go below 800 wide, so neither half preset lands where
+	// it was asked to. The app name is lowercase, as clients often send it.
+	h := newHandlers(&applescripttest.Runner{Respond: newMovableWindow(800).respond})
+	ctx := context.Background()
+
+	for _, pos := range []string{"left-half", "right-half"} {
+		if _, _, err := h.MoveAppToScreen(ctx, nil, MoveAppToScreenArgs{AppName: "safari", Position: pos}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, got, err := h.RestoreWindow(ctx, nil, RestoreWindowArgs{AppName: "safari"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.X != 100 || got.Y != 100 || got.Width != 1600 || got.Height != 900 {
+		t.Errorf("restore = %+v, want the user's frame (100,100) 1600x900", got)
+	}
+}
+
+func TestResizeAppWindowKeepsCenter(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: fakeMac})
+	_, got, err := h.ResizeAppWindow(context.Background(), nil, ResizeAppWindowArgs{
+		AppName:             "QuickTime Player",
+		Width:               800,
+		Anchor:              "center",
+		PreserveAspectRatio: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.X != 500 || got.Y != 325 || got.Width != 800 || got.Height != 450 || got.WindowIndex != 1 {
+		t.Errorf("result = %+v, want window 1 at (500,325) 800x450", got)
+	}
+}
+
+func TestResizeWindowCellsMetrics(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeMac}
+	h := newHandlers(r)
+	_, got, err := h.ResizeWindowCells(context.Background(), nil, ResizeWindowCellsArgs{
+		AppName: "Alacritty",
+		Columns: 120,
+		Rows:    40,
+		Metrics: &layout.CellMetrics{CellWidth: 7.5, CellHeight: 16, PaddingX: 10, PaddingY: 38},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Mode != "metrics" || got.X != 100 || got.Y != 100 || got.Width != 910 || got.Height != 678 {
+		t.Errorf("result = %+v, want 910x678 at the current position", got)
+	}
+}
+
+func TestResizeWindowCellsNative(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeMac}
+	h := newHandlers(r)
+	_, got, err := h.ResizeWindowCells(context.Background(), nil, ResizeWindowCellsArgs{
+		AppName: "Terminal",
+		Columns: 120,
+		Rows:    40,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Mode != "native" {
+		t.Errorf("mode = %q, want native", got.Mode)
+	}
+	for _, s := range r.Scripts() {
+		if strings.Contains(s, "set position") {
+			t.Errorf("native sizing without x/y moved the window:\n%s", s)
+		}
+	}
+}
+
+func TestResizeWindowCellsNeedsMetrics(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: fakeMac})
+	_, _, err := h.ResizeWindowCells(context.Background(), nil, ResizeWindowCellsArgs{AppName: "Alacritty", Columns: 80, Rows: 24})
+	if err == nil || !strings.Contains(err.Error(), "pass metrics") {
+		t.Errorf("err = %v, want a request for metrics", err)
+	}
+}
+
+func TestListAllScreensArrangement(t *testing.T) {
+	r := &applescripttest.Runner{Respond: func(c applescripttest.Call) (string, error) {
+		if strings.Contains(c.Script, "CGDisplayBounds") {
+			return `[
+				{"id": 1, "name": "Built-in", "x": 0, "y": 0, "w": 1440, "h": 900, "main": true, "builtin": true},
+				{"id": 2, "name": "Studio Display", "x": -300, "y": -1440, "w": 2560, "h": 1440}
+			]`, nil
+		}
+		return fakeMac(c)
+	}}
+	res, got, err := newHandlers(r).ListAllScreens(context.Background(), nil, struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Source != "coregraphics" || len(got.Arrangement) != 2 {
+		t.Fatalf("result = %+v, want two CoreGraphics adjacencies", got)
+	}
+	text := res.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "Studio Display (1) is above Built-in (0)") {
+		t.Errorf("text %q does not describe the arrangement", text)
+	}
+}
+
+// deniedMac is fakeMac with System Events blocked by Automation permission.
+func deniedMac(c applescripttest.Call) (string, error) {
+	if c.Kind == applescripttest.AppleScript && strings.Contains(c.Script, "System Events") {
+		return "", errors.New(`osascript: execution error: Not authorized to send Apple events to System Events. (-1743)`)
+	}
+	return fakeMac(c)
+}
+
+func TestDegradedModeFallsBackToCoreGraphics(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: deniedMac})
+	ctx := context.Background()
+
+	res, list, err := h.ListAllWindows(ctx, nil, ListAllWindowsArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !list.DegradedMode || len(list.Unavailable) == 0 {
+		t.Errorf("ListAllWindows degraded = %+v, want degraded mode with unavailable capabilities", list.Degraded)
+	}
+	if list.Count != 1 || list.Windows[0].AppName != "Safari" {
+		t.Errorf("ListAllWindows windows = %+v, want the Safari window", list.Windows)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "degraded mode") {
+		t.Errorf("text = %q, want degraded mode note", text)
+	}
+
+	_, geom, err := h.GetAppWindowGeometry(ctx, nil, GetWindowArgs{AppName: "safari"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !geom.DegradedMode || geom.X != 100 || geom.Width != 1600 {
+		t.Errorf("GetAppWindowGeometry = %+v, want degraded CoreGraphics frame", geom)
+	}
+	if geom.TitlesUnavailable == nil {
+		t.Error("GetAppWindowGeometry: want Screen Recording hint for empty titles")
+	}
+}
+
+func TestExplainDeniedAddsRemedy(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: deniedMac})
+	_, _, err := explainDenied(h.MoveAppToScreen)(context.Background(), nil, MoveAppToScreenArgs{
+		AppName:  "Safari",
+		Position: "maximize",
+	})
+	if err == nil || !strings.Contains(err.Error(), "Automation (System Events)") {
+		t.Fatalf("err = %v, want Automation remedy", err)
+	}
+	// Only tools with a CoreGraphics fallback may be promised to keep working.
+	for _, tool := range []string{"get_recent_windows", "list_all_screens"} {
+		if strings.Contains(err.Error(), tool) {
+			t.Errorf("err = %v, names %s as a degraded-mode tool", err, tool)
+		}
+	}
+}
+
+func TestCleanupProcessesRejectsNegativeAge(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: fakeMac})
+	age := -1
+	_, _, err := h.CleanupProcesses(context.Background(), nil, CleanupProcessesArgs{OlderThanSeconds: &age})
+	if err == nil || !strings.Contains(err.Error(), "must not be negative") {
+		t.Errorf("err = %v, want negative age error", err)
+	}
+}
+
+func TestCleanupProcessesDryRun(t *testing.T) {
+	r := &applescripttest.Runner{Respond: func(c applescripttest.Call) (string, error) {
+		if c.Name == "ps" {
+			return "  1234     1 S    01:00:00 /usr/bin/osascript", nil
+		}
+		return fakeMac(c)
+	}}
+	h := newHandlers(r)
+	_, res, err := h.CleanupProcesses(context.Background(), nil, CleanupProcessesArgs{KillOrphans: true, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Count != 1 || res.Leftovers[0].Reason != "orphaned" || res.Leftovers[0].Action != "reported" {
+		t.Errorf("leftovers = %+v, want one reported orphan", res.Leftovers)
+	}
+}
+
+func TestGetRecentWindowsExplainsMissingTitles(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: fakeMac})
+	res, got, err := h.GetRecentWindows(context.Background(), nil, GetRecentWindowsArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TitlesUnavailable == nil || got.TitlesUnavailable.Permission != "screen-recording" {
+		t.Errorf("TitlesUnavailable = %+v, want screen-recording hint", got.TitlesUnavailable)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "request_screen_permission") {
+		t.Errorf("text = %q, want permission hint", text)
+	}
+}
+
+// dockMac is fakeMac with a 25px menu bar and a 70px Dock on the display.
+func dockMac(c applescripttest.Call) (string, error) {
+	if strings.Contains(c.Script, "visibleFrame") {
+		return `[{"frame": {"x": 0, "y": 0, "w": 1440, "h": 900}, "visible": {"x": 0, "y": 70, "w": 1440, "h": 805}}]`, nil
+	}
+	return fakeMac(c)
+}
+
+func TestMoveAppToScreenPresetAvoidsMenuBarAndDock(t *testing.T) {
+	r := &applescripttest.Runner{Respond: dockMac}
+	h := newHandlers(r)
+	res, _, err := h.MoveAppToScreen(context.Background(), nil, MoveAppToScreenArgs{AppName: "Safari", Position: "maximize"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := res.Content[0].(*mcp.TextContent).Text
+	if strings.Contains(text, "warning") {
+		t.Errorf("text = %q, want no warnings for a preset", text)
+	}
+	if !strings.Contains(text, "(0,25) 1440x805") {
+		t.Errorf("text = %q, want the visible frame (0,25) 1440x805", text)
+	}
+}
+
+func TestMoveAppToScreenReadsFramesOnce(t *testing.T) {
+	r := &applescripttest.Runner{Respond: fakeMac}
+	h := newHandlers(r)
+	if _, _, err := h.MoveAppToScreen(context.Background(), nil, MoveAppToScreenArgs{AppName: "Safari", Position: "left-half"}); err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for _, s := range r.Scripts() {
+		if strings.Contains(s, "visibleFrame") {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("read the visible frames %d times, want once for the preset and pre-flight", n)
+	}
+}
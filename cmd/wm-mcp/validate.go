@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/display"
+	"github.com/bad33ndj3/mcp-macos-window-manager/layout"
+	"github.com/bad33ndj3/mcp-macos-window-manager/windowmgr"
+)
+
+// visibleFrames returns the screen frames, or nil when they cannot be read.
+// Handlers fetch them once per call and share them between preset areas and
+// preflight.
+func (h *handlers) visibleFrames(ctx context.Context) []display.ScreenFrame {
+	frames, err := h.displays.VisibleFrames(ctx)
+	if err != nil {
+		return nil
+	}
+	return frames
+}
+
+// preflight checks a frame a mutating tool is about to apply against frames
+// from visibleFrames. It never blocks the move: without frames it returns
+// nil.
+func (h *handlers) preflight(frames []display.ScreenFrame, appName string, r layout.Rect) []string {
+	if len(frames) == 0 {
+		return nil
+	}
+	minSize, _ := h.minSizes.Get(appName)
+	return layout.CheckPlacement(r, frames, minSize.Width, minSize.Height)
+}
+
+// learnMinSize reads a window's frame back after a move. Apps silently
+// enforce a minimum size, so a window larger than requested reveals it for
+// later pre-flights. It returns the frame the window actually has, or r when
+// it cannot be read.
+func (h *handlers) learnMinSize(ctx context.Context, appName string, windowIndex int, r layout.Rect) windowmgr.Geometry {
+	g, err := h.wm.WindowGeometry(ctx, appName, windowIndex)
+	if err != nil {
+		return windowmgr.Geometry{AppName: appName, X: r.X, Y: r.Y, Width: r.Width, Height: r.Height}
+	}
+	h.minSizes.Learn(appName,
+		windowmgr.Size{Width: r.Width, Height: r.Height},
+		windowmgr.Size{Width: g.Width, Height: g.Height})
+	return g
+}
+
+// withWarnings appends pre-flight warnings to a tool's result text.
+func withWarnings(text string, warnings []string) string {
+	if len(warnings) == 0 {
+		return text
+	}
+	return text + " (warning: " + strings.Join(warnings, "; ") + ")"
+}
+
+// ---------- Tool 22: Validate a placement before applying it ----------
+
+type ValidatePlacementArgs struct {
+	AppName string `json:"appName,omitempty" jsonschema:"Application the window belongs to (enables minimum-size checks)"`
+	X       int    `json:"x" jsonschema:"X position in pixels"`
+	Y       int    `json:"y" jsonschema:"Y position in pixels"`
+	Width   int    `json:"width" jsonschema:"Window width in pixels"`
+	Height  int    `json:"height" jsonschema:"Window height in pixels"`
+}
+
+type ValidatePlacementResult struct {
+	OK       bool            `json:"ok" jsonschema:"True when no problems were found"`
+	Warnings []string        `json:"warnings,omitempty" jsonschema:"Problems with the proposed frame"`
+	MinSize  *windowmgr.Size `json:"minSize,omitempty" jsonschema:"Minimum size learned for the app, if any"`
+}
+
+func (h *handlers) ValidatePlacement(ctx context.Context, req *mcp.CallToolRequest, args ValidatePlacementArgs) (*mcp.CallToolResult, ValidatePlacementResult, error) {
+	screens, err := h.displays.VisibleFrames(ctx)
+	if err != nil {
+		return nil, ValidatePlacementResult{}, fmt.Errorf("failed to get screen frames: %w", err)
+	}
+
+	var result ValidatePlacementResult
+	var minSize windowmgr.Size
+	if args.AppName != "" {
+		if s, ok := h.minSizes.Get(args.AppName); ok {
+			minSize = s
+			result.MinSize = &s
+		}
+	}
+	r := layout.Rect{X: args.X, Y: args.Y, Width: args.Width, Height: args.Height}
+	result.Warnings = layout.CheckPlacement(r, screens, minSize.Width, minSize.Height)
+	result.OK = len(result.Warnings) == 0
+
+	text := fmt.Sprintf("Placement (%d,%d) %dx%d looks fine", args.X, args.Y, args.Width, args.Height)
+	if !result.OK {
+		text = fmt.Sprintf("Placement (%d,%d) %dx%d has problems:\n- %s", args.X, args.Y, args.Width, args.Height, strings.Join(result.Warnings, "\n- "))
+	}
+	return textResult(text), result, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+func TestValidatePlacementOffScreen(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: fakeMac})
+	_, got, err := h.ValidatePlacement(context.Background(), nil, ValidatePlacementArgs{
+		X: 1040, Y: 0, Width: 800, Height: 600,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.OK || len(got.Warnings) != 1 || got.Warnings[0] != "50% of the window will be off-screen" {
+		t.Errorf("result = %+v, want a single 50%% off-screen warning", got)
+	}
+}
+
+func TestMoveLearnsAppMinimum(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{Respond: fakeMac})
+	ctx := context.Background()
+
+	// The fake reports every window as 1600x900 after the move, as an app
+	// with that minimum size would.
+	if _, _, err := h.MoveResizeApp(ctx, nil, MoveResizeArgs{AppName: "Safari", X: 0, Y: 0, Width: 800, Height: 600}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, got, err := h.ValidatePlacement(ctx, nil, ValidatePlacementArgs{
+		AppName: "Safari", X: 0, Y: 0, Width: 800, Height: 600,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.MinSize == nil || got.MinSize.Width != 1600 || got.MinSize.Height != 900 {
+		t.Fatalf("MinSize = %+v, want 1600x900", got.MinSize)
+	}
+	if got.OK || len(got.Warnings) != 2 || !strings.Contains(got.Warnings[0], "below the app minimum of 1600") {
+		t.Errorf("warnings = %q, want width and height below minimum", got.Warnings)
+	}
+
+	res, _, err := h.MoveResizeApp(ctx, nil, MoveResizeArgs{AppName: "Safari", X: 0, Y: 0, Width: 800, Height: 600})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "warning: width 800 is below") {
+		t.Errorf("move result %q does not carry the pre-flight warning", text)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/windowmgr"
+)
+
+// windowWatch is a registered watch_for_window subscription.
+type windowWatch struct {
+	ID      string `json:"watchId" jsonschema:"Watch ID"`
+	AppName string `json:"appName,omitempty" jsonschema:"Application name pattern"`
+	Title   string `json:"title,omitempty" jsonschema:"Window title pattern"`
+	Events  string `json:"events" jsonschema:"Reported events: appeared, disappeared or both"`
+
+	seq     int
+	watcher *windowmgr.Watcher
+	cancel  context.CancelFunc
+}
+
+// watchRegistry tracks active watches so they can be listed and stopped.
+type watchRegistry struct {
+	mu      sync.Mutex
+	nextID  int
+	watches map[string]*windowWatch
+	closed  bool // set by stopAll; the session has ended
+}
+
+// add registers w and assigns its ID. It fails once the owning session has
+// ended, since nothing would ever stop the watch.
+func (r *watchRegistry) add(w *windowWatch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return fmt.Errorf("the client session has ended")
+	}
+	if r.watches == nil {
+		r.watches = make(map[string]*windowWatch)
+	}
+	r.nextID++
+	w.seq = r.nextID
+	w.ID = "watch-" + strconv.Itoa(w.seq)
+	r.watches[w.ID] = w
+	return nil
+}
+
+func (r *watchRegistry) remove(id string) (*windowWatch, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w, ok := r.watches[id]
+	if ok {
+		w.cancel()
+		delete(r.watches, id)
+	}
+	return w, ok
+}
+
+// stopAll cancels every watch and refuses new ones, for when the owning
+// session ends.
+func (r *watchRegistry) stopAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	for id, w := range r.watches {
+		w.cancel()
+		delete(r.watches, id)
+	}
+}
+
+func (r *watchRegistry) list() []*windowWatch {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	watches := make([]*windowWatch, 0, len(r.watches))
+	for _, w := range r.watches {
+		watches = append(watches, w)
+	}
+	sort.Slice(watches, func(i, j int) bool { return watches[i].seq < watches[j].seq })
+	return watches
+}
+
+// WindowWatchNotification is the data of the notifications/message sent for
+// each watch event.
+type WindowWatchNotification struct {
+	WatchID string               `json:"watchId"`
+	Event   string               `json:"event"`
+	Window  windowmgr.WindowInfo `json:"window"`
+}
+
+// WindowWatchError is the data of the warning sent when the window list
+// keeps failing, so a watch that cannot see anything does not stay silent.
+type WindowWatchError struct {
+	WatchID string `json:"watchId"`
+	Error   string `json:"error"`
+}
+
+// ---------- Tool 14: Watch for windows appearing / disappearing ----------
+
+type WatchForWindowArgs struct {
+	AppName string `json:"appName,omitempty" jsonschema:"Application name to match (case-insensitive substring), e.g. 'zoom'"`
+	Title   string `json:"title,omitempty" jsonschema:"Regular expression matched against window titles, e.g. '^Zoom Meeting'"`
+	Events  string `json:"events,omitempty" jsonschema:"Which events to report: 'appeared', 'disappeared' or 'both' (default)"`
+}
+
+type WatchForWindowResult struct {
+	WatchID  string                 `json:"watchId" jsonschema:"ID to pass to unwatch_window"`
+	Matching []windowmgr.WindowInfo `json:"matching" jsonschema:"Matching windows already open (not reported as events)"`
+}
+
+func (h *handlers) WatchForWindow(ctx context.Context, req *mcp.CallToolRequest, args WatchForWindowArgs) (*mcp.CallToolResult, WatchForWindowResult, error) {
+	events := args.Events
+	if events == "" {
+		events = "both"
+	}
+	if events != "both" && events != windowmgr.WindowAppeared && events != windowmgr.WindowDisappeared {
+		return nil, WatchForWindowResult{}, fmt.Errorf("invalid events: %q (valid: appeared, disappeared, both)", args.Events)
+	}
+
+	watcher, err := windowmgr.NewWatcher(windowmgr.WatchSpec{AppName: args.AppName, Title: args.Title})
+	if err != nil {
+		return nil, WatchForWindowResult{}, err
+	}
+
+	// The watch outlives this request; it ends on unwatch_window or when the
+	// client session closes (see handlers.client).
+	watchCtx, cancel := context.WithCancel(context.Background())
+	w := &windowWatch{
+		AppName: args.AppName,
+		Title:   args.Title,
+		Events:  events,
+		watcher: watcher,
+		cancel:  cancel,
+	}
+	watches := &h.client(req).watches
+	if err := watches.add(w); err != nil {
+		cancel()
+		return nil, WatchForWindowResult{}, err
+	}
+
+	var session *mcp.ServerSession
+	if req != nil {
+		session = req.Session
+	}
+	notify := func(e windowmgr.WatchEvent) {
+		if session == nil || (events != "both" && events != e.Type) {
+			return
+		}
+		_ = session.Log(watchCtx, &mcp.LoggingMessageParams{
+			Level:  "info",
+			Logger: "watch_for_window",
+			Data:   WindowWatchNotification{WatchID: w.ID, Event: e.Type, Window: e.Window},
+		})
+	}
+	warn := func(err error) {
+		if session == nil {
+			return
+		}
+		_ = session.Log(watchCtx, &mcp.LoggingMessageParams{
+			Level:  "warning",
+			Logger: "watch_for_window",
+			Data:   WindowWatchError{WatchID: w.ID, Error: err.Error()},
+		})
+	}
+
+	if err := h.watcher.Subscribe(watchCtx, watcher, notify, warn); err != nil {
+		watches.remove(w.ID)
+		return nil, WatchForWindowResult{}, err
+	}
+
+	matching := watcher.Current()
+	text := fmt.Sprintf("Watching for windows (app=%q title=%q, events=%s) as %s; %d matching window(s) already open. "+
+		"Events are sent as notifications/message (logger 'watch_for_window'); set a logging level to receive them.",
+		args.AppName, args.Title, events, w.ID, len(matching))
+	return textResult(text), WatchForWindowResult{
+		WatchID:  w.ID,
+		Matching: matching,
+	}, nil
+}
+
+// ---------- Tool 15: Stop a window watch ----------
+
+type UnwatchWindowArgs struct {
+	WatchID string `json:"watchId" jsonschema:"ID returned by watch_for_window"`
+}
+
+func (h *handlers) UnwatchWindow(ctx context.Context, req *mcp.CallToolRequest, args UnwatchWindowArgs) (*mcp.CallToolResult, any, error) {
+	if args.WatchID == "" {
+		return nil, nil, fmt.Errorf("watchId is required")
+	}
+	if _, ok := h.client(req).watches.remove(args.WatchID); !ok {
+		return nil, nil, fmt.Errorf("no active watch %q", args.WatchID)
+	}
+	return textResult(fmt.Sprintf("Stopped %s", args.WatchID)), nil, nil
+}
+
+// ---------- Tool 16: List active window watches ----------
+
+type ListWindowWatchesResult struct {
+	Watches []*windowWatch `json:"watches" jsonschema:"Active watches"`
+	Count   int            `json:"count" jsonschema:"Number of active watches"`
+}
+
+func (h *handlers) ListWindowWatches(ctx context.Context, req *mcp.CallToolRequest, _ struct{}) (*mcp.CallToolResult, ListWindowWatchesResult, error) {
+	watches := h.client(req).watches.list()
+	return textResult(fmt.Sprintf("%d active window watch(es)", len(watches))), ListWindowWatchesResult{
+		Watches: watches,
+		Count:   len(watches),
+	}, nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+func TestWindowWatchLifecycle(t *testing.T) {
+	ctx := context.Background()
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs("zoom.us|Zoom Meeting|0|0|800|600;Safari|Apple|0|0|10|10")}
+	h := newHandlers(r)
+
+	_, res, err := h.WatchForWindow(ctx, nil, WatchForWindowArgs{Title: "^Zoom Meeting"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.WatchID == "" || len(res.Matching) != 1 {
+		t.Fatalf("result = %+v, want an ID and one matching window", res)
+	}
+
+	_, list, _ := h.ListWindowWatches(ctx, nil, struct{}{})
+	if list.Count != 1 || list.Watches[0].ID != res.WatchID || list.Watches[0].Events != "both" {
+		t.Errorf("watches = %+v", list.Watches)
+	}
+
+	if _, _, err := h.UnwatchWindow(ctx, nil, UnwatchWindowArgs{WatchID: res.WatchID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := h.UnwatchWindow(ctx, nil, UnwatchWindowArgs{WatchID: res.WatchID}); err == nil {
+		t.Error("second unwatch: want error")
+	}
+	if _, list, _ := h.ListWindowWatches(ctx, nil, struct{}{}); list.Count != 0 {
+		t.Errorf("%d watches left after unwatch", list.Count)
+	}
+}
+
+func TestWatchForWindowValidation(t *testing.T) {
+	h := newHandlers(&applescripttest.Runner{})
+	for name, args := range map[string]WatchForWindowArgs{
+		"no pattern": {},
+		"bad events": {AppName: "zoom", Events: "moved"},
+		"bad regexp": {Title: "["},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := h.WatchForWindow(context.Background(), nil, args); err == nil {
+				t.Error("want error")
+			}
+		})
+	}
+	if _, list, _ := h.ListWindowWatches(context.Background(), nil, struct{}{}); list.Count != 0 {
+		t.Errorf("failed registrations left %d watches", list.Count)
+	}
+}
+
+func TestListWindowWatchesNumericOrder(t *testing.T) {
+	var r watchRegistry
+	for i := 0; i < 10; i++ {
+		r.add(&windowWatch{cancel: func() {}})
+	}
+	var ids []string
+	for _, w := range r.list() {
+		ids = append(ids, w.ID)
+	}
+	if ids[1] != "watch-2" || ids[9] != "watch-10" {
+		t.Errorf("ids = %v, want watch-1 .. watch-10 in numeric order", ids)
+	}
+}
@@ -0,0 +1,60 @@
+package display
+
+// Sides of a display a neighbor can touch.
+const (
+	SideLeft  = "left"
+	SideRight = "right"
+	SideAbove = "above"
+	SideBelow = "below"
+)
+
+// Adjacency says that display Neighbor touches display Display on Side.
+type Adjacency struct {
+	Display  int    `json:"display" jsonschema:"Display index"`
+	Side     string `json:"side" jsonschema:"Where the neighbor is relative to the display: 'left', 'right', 'above' or 'below'"`
+	Neighbor int    `json:"neighbor" jsonschema:"Index of the neighboring display"`
+}
+
+// edgeTolerance absorbs rounding of fractional display origins.
+const edgeTolerance = 1
+
+// Arrange computes the arrangement graph of displays: for every pair of
+// displays that share part of an edge, one Adjacency in each direction.
+// Displays that only meet at a corner are not adjacent. The result is
+// ordered by Display, then by the order of the neighbors in displays.
+func Arrange(displays []Info) []Adjacency {
+	var graph []Adjacency
+	for _, a := range displays {
+		for _, b := range displays {
+			if a.Index == b.Index {
+				continue
+			}
+			if side, ok := sideOf(a, b); ok {
+				graph = append(graph, Adjacency{Display: a.Index, Side: side, Neighbor: b.Index})
+			}
+		}
+	}
+	return graph
+}
+
+// sideOf returns on which side of a display b lies, if they share an edge.
+func sideOf(a, b Info) (string, bool) {
+	vertical := min(a.Bottom, b.Bottom) - max(a.Top, b.Top)
+	horizontal := min(a.Right, b.Right) - max(a.Left, b.Left)
+	switch {
+	case vertical > 0 && near(b.Left, a.Right):
+		return SideRight, true
+	case vertical > 0 && near(b.Right, a.Left):
+		return SideLeft, true
+	case horizontal > 0 && near(b.Bottom, a.Top):
+		return SideAbove, true
+	case horizontal > 0 && near(b.Top, a.Bottom):
+		return SideBelow, true
+	}
+	return "", false
+}
+
+func near(x, y int) bool {
+	d := x - y
+	return d >= -edgeTolerance && d <= edgeTolerance
+}
@@ -0,0 +1,34 @@
+package display
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArrange(t *testing.T) {
+	// A laptop with a monitor above it, a portrait monitor to the right of
+	// both, and a display that only touches the laptop's corner.
+	laptop := Info{Index: 0, Left: 0, Top: 0, Right: 1512, Bottom: 982}
+	above := Info{Index: 1, Left: -400, Top: -1440, Right: 1512, Bottom: 0}
+	right := Info{Index: 2, Left: 1512, Top: -500, Right: 2592, Bottom: 1420}
+	corner := Info{Index: 3, Left: -1920, Top: 982, Right: 0, Bottom: 2062}
+
+	got := Arrange([]Info{laptop, above, right, corner})
+	want := []Adjacency{
+		{Display: 0, Side: SideAbove, Neighbor: 1},
+		{Display: 0, Side: SideRight, Neighbor: 2},
+		{Display: 1, Side: SideBelow, Neighbor: 0},
+		{Display: 1, Side: SideRight, Neighbor: 2},
+		{Display: 2, Side: SideLeft, Neighbor: 0},
+		{Display: 2, Side: SideLeft, Neighbor: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Arrange =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestArrangeSingleDisplay(t *testing.T) {
+	if got := Arrange([]Info{{Index: 0, Right: 1440, Bottom: 900}}); got != nil {
+		t.Errorf("Arrange = %+v, want nil", got)
+	}
+}
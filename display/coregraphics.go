@@ -0,0 +1,140 @@
+package display
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// coreGraphicsScript lists active displays in NSScreen order (the menu bar
+// display first) with their CoreGraphics attributes. CGDisplayBounds is
+// already in global top-left coordinates. A mirror set is one NSScreen, so
+// it appears as a single display with mirrored set; the other members of the
+// set come from CGGetOnlineDisplayList, with mirrorOf naming the display
+// they copy (CGDisplayMirrorsDisplay). The display UUID is the identifier
+// the window server uses for Spaces (Space.DisplayID).
+const coreGraphicsScript = `
+ObjC.import("AppKit");
+ObjC.import("CoreGraphics");
+ObjC.bindFunction("CGDisplayCreateUUIDFromDisplayID", ["void *", ["unsigned int"]]);
+ObjC.bindFunction("CFUUIDCreateString", ["id", ["void *", "void *"]]);
+function uuidOf(id) {
+	try { return ObjC.unwrap($.CFUUIDCreateString(null, $.CGDisplayCreateUUIDFromDisplayID(id))); } catch (e) { return ""; }
+}
+var screens = $.NSScreen.screens;
+var out = [];
+for (var i = 0; i < screens.count; i++) {
+	var s = screens.objectAtIndex(i);
+	var id = s.deviceDescription.objectForKey("NSScreenNumber").unsignedIntValue;
+	var b = $.CGDisplayBounds(id);
+	var name = "";
+	try { name = s.localizedName.js; } catch (e) {}
+	out.push({
+		id: id,
+		uuid: uuidOf(id),
+		name: name,
+		x: b.origin.x, y: b.origin.y, w: b.size.width, h: b.size.height,
+		main: $.CGDisplayIsMain(id) == 1,
+		builtin: $.CGDisplayIsBuiltin(id) == 1,
+		mirrored: $.CGDisplayIsInMirrorSet(id) == 1,
+		rotation: $.CGDisplayRotation(id)
+	});
+}
+try {
+	var count = Ref();
+	$.CGGetOnlineDisplayList(0, null, count);
+	var ids = Ref();
+	$.CGGetOnlineDisplayList(count[0], ids, count);
+	for (var j = 0; j < count[0]; j++) {
+		var mid = ids[j];
+		var of = $.CGDisplayMirrorsDisplay(mid);
+		if (of != 0) {
+			out.push({id: mid, uuid: uuidOf(mid), mirrorOf: of, builtin: $.CGDisplayIsBuiltin(mid) == 1});
+		}
+	}
+} catch (e) {}
+JSON.stringify(out);
+`
+
+// Mirror is a display that shows a copy of another display. It adds no area
+// to the desktop, so it is listed apart from Screens.Displays and cannot be
+// a placement target.
+type Mirror struct {
+	DisplayID uint32 `json:"displayId" jsonschema:"CoreGraphics display ID"`
+	UUID      string `json:"uuid,omitempty" jsonschema:"Display UUID"`
+	MirrorOf  uint32 `json:"mirrorOf" jsonschema:"displayId of the listed display this one mirrors"`
+	Builtin   bool   `json:"builtin,omitempty" jsonschema:"Whether this is a built-in (laptop) display"`
+}
+
+type cgDisplay struct {
+	ID       uint32  `json:"id"`
+	UUID     string  `json:"uuid"`
+	Name     string  `json:"name"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	W        float64 `json:"w"`
+	H        float64 `json:"h"`
+	Main     bool    `json:"main"`
+	Builtin  bool    `json:"builtin"`
+	Mirrored bool    `json:"mirrored"`
+	Rotation float64 `json:"rotation"`
+	MirrorOf uint32  `json:"mirrorOf"`
+}
+
+// listCoreGraphics returns the active displays as reported by CoreGraphics,
+// and the displays that mirror them.
+func (c *Client) listCoreGraphics(ctx context.Context) ([]Info, []Mirror, error) {
+	out, err := c.run.RunJXA(ctx, coreGraphicsScript)
+	if err != nil {
+		return nil, nil, err
+	}
+	return parseCoreGraphics(out)
+}
+
+func parseCoreGraphics(out string) ([]Info, []Mirror, error) {
+	var raw []cgDisplay
+	if err := json.Unmarshal([]byte(out), &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse display list: %w", err)
+	}
+
+	displays := make([]Info, 0, len(raw))
+	var mirrors []Mirror
+	for _, d := range raw {
+		if d.MirrorOf != 0 {
+			mirrors = append(mirrors, Mirror{DisplayID: d.ID, UUID: d.UUID, MirrorOf: d.MirrorOf, Builtin: d.Builtin})
+			continue
+		}
+		if d.W <= 0 || d.H <= 0 {
+			return nil, nil, fmt.Errorf("display %d has no size", d.ID)
+		}
+		left := int(math.Round(d.X))
+		top := int(math.Round(d.Y))
+		width := int(math.Round(d.W))
+		height := int(math.Round(d.H))
+		rotation := int(math.Round(d.Rotation)) % 360
+
+		name := d.Name
+		if name == "" {
+			name = fmt.Sprintf("Display %d", d.ID)
+		}
+		displays = append(displays, Info{
+			Index:           len(displays),
+			Name:            name,
+			Left:            left,
+			Top:             top,
+			Right:           left + width,
+			Bottom:          top + height,
+			Width:           width,
+			Height:          height,
+			IsMain:          d.Main,
+			Rotated:         rotation == 90 || rotation == 270 || height > width,
+			DisplayID:       d.ID,
+			UUID:            d.UUID,
+			Builtin:         d.Builtin,
+			RotationDegrees: rotation,
+			Mirrored:        d.Mirrored,
+		})
+	}
+	return displays, mirrors, nil
+}
@@ -0,0 +1,279 @@
+// Package display reports the macOS desktop geometry: the bounds of the
+// virtual desktop, the connected displays and the active Spaces.
+//
+// Coordinates use the macOS global screen space where (0,0) is the top-left
+// of the main display (the one with the menu bar). Displays to the left have
+// negative X and displays above have negative Y.
+package display
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript"
+)
+
+// Client queries display information through a Runner.
+type Client struct {
+	run applescript.Runner
+}
+
+// New returns a Client that executes scripts and commands with r.
+func New(r applescript.Runner) *Client {
+	return &Client{run: r}
+}
+
+// Bounds is a rectangle in global screen coordinates.
+type Bounds struct {
+	Left   int `json:"left" jsonschema:"Left coordinate in pixels"`
+	Top    int `json:"top" jsonschema:"Top coordinate in pixels"`
+	Right  int `json:"right" jsonschema:"Right coordinate in pixels"`
+	Bottom int `json:"bottom" jsonschema:"Bottom coordinate in pixels"`
+	Width  int `json:"width" jsonschema:"Width in pixels (right-left)"`
+	Height int `json:"height" jsonschema:"Height in pixels (bottom-top)"`
+}
+
+const desktopBoundsScript = `
+tell application "Finder"
+	set b to bounds of window of desktop
+	set {l, t, r, btm} to b
+	return l & "," & t & "," & r & "," & btm
+end tell
+`
+
+// MainBounds returns the bounds of the Finder desktop window, which
+// corresponds to the current Space. With multiple displays, coords can be a
+// virtual desktop (e.g. negative X for left displays).
+func (c *Client) MainBounds(ctx context.Context) (Bounds, error) {
+	out, err := c.run.RunAppleScript(ctx, desktopBoundsScript)
+	if err != nil {
+		return Bounds{}, err
+	}
+
+	vals, err := applescript.ParseCSVInts(out, 4)
+	if err != nil {
+		return Bounds{}, err
+	}
+
+	return Bounds{
+		Left:   vals[0],
+		Top:    vals[1],
+		Right:  vals[2],
+		Bottom: vals[3],
+		Width:  vals[2] - vals[0],
+		Height: vals[3] - vals[1],
+	}, nil
+}
+
+// Info describes one connected display.
+type Info struct {
+	Index   int    `json:"index" jsonschema:"Display index (0 = main display with menu bar)"`
+	Name    string `json:"name" jsonschema:"Display name"`
+	Left    int    `json:"left" jsonschema:"Left coordinate in pixels"`
+	Top     int    `json:"top" jsonschema:"Top coordinate in pixels"`
+	Right   int    `json:"right" jsonschema:"Right coordinate in pixels"`
+	Bottom  int    `json:"bottom" jsonschema:"Bottom coordinate in pixels"`
+	Width   int    `json:"width" jsonschema:"Width in pixels"`
+	Height  int    `json:"height" jsonschema:"Height in pixels"`
+	IsMain  bool   `json:"isMain" jsonschema:"Whether this is the main display with menu bar"`
+	Rotated bool   `json:"rotated" jsonschema:"Whether this display is rotated to portrait orientation"`
+
+	// Only reported by CoreGraphics (Screens.Source == SourceCoreGraphics).
+	DisplayID       uint32 `json:"displayId,omitempty" jsonschema:"CoreGraphics display ID"`
+	UUID            string `json:"uuid,omitempty" jsonschema:"Display UUID, the displayId that get_current_space reports for this display"`
+	Builtin         bool   `json:"builtin,omitempty" jsonschema:"Whether this is a built-in (laptop) display"`
+	RotationDegrees int    `json:"rotationDegrees,omitempty" jsonschema:"Rotation in degrees: 0, 90, 180 or 270"`
+	Mirrored        bool   `json:"mirrored,omitempty" jsonschema:"Whether this display is part of a mirror set; the displays copying it are listed in mirrors"`
+}
+
+// Sources of the display list in Screens.Source.
+const (
+	// SourceCoreGraphics is the real arrangement from CoreGraphics.
+	SourceCoreGraphics = "coregraphics"
+	// SourceSystemProfiler guesses positions: displays are laid out left to
+	// right with their tops aligned.
+	SourceSystemProfiler = "system_profiler"
+	// SourceDesktop is a single display spanning the Finder desktop.
+	SourceDesktop = "desktop"
+)
+
+// Screens is the result of List.
+type Screens struct {
+	Displays []Info
+	// Arrangement is the adjacency graph of Displays (see Arrange). It is
+	// only exact when Source is SourceCoreGraphics.
+	Arrangement []Adjacency
+	// Source says where Displays came from.
+	Source string
+	// Desktop is the whole virtual desktop: the union of the display bounds
+	// from CoreGraphics, otherwise as reported by Finder.
+	Desktop Bounds
+	// Fallback is set when per-display information was unavailable and
+	// Displays holds a single display spanning the desktop.
+	Fallback bool
+	// Mirrors are the displays copying one of Displays. Only reported by
+	// CoreGraphics.
+	Mirrors []Mirror
+}
+
+type systemProfilerDisplay struct {
+	Resolution string `json:"_spdisplays_resolution"`
+	Main       string `json:"spdisplays_main"`
+	Name       string `json:"_name"`
+}
+
+type systemProfilerData struct {
+	SPDisplaysDataType []struct {
+		Name     string                  `json:"_name"`
+		Displays []systemProfilerDisplay `json:"spdisplays_ndrvs"`
+	} `json:"SPDisplaysDataType"`
+}
+
+// List returns all connected displays. Pure AppleScript cannot reliably
+// enumerate displays, so this asks CoreGraphics (through JXA) for the real
+// arrangement; that needs no Automation permission, so it keeps working when
+// Finder and System Events are blocked. If it fails, List combines the
+// Finder desktop bounds with `system_profiler SPDisplaysDataType -json`, and
+// finally falls back to a single display spanning the desktop.
+func (c *Client) List(ctx context.Context) (Screens, error) {
+	if displays, mirrors, err := c.listCoreGraphics(ctx); err == nil && len(displays) > 0 {
+		return Screens{
+			Displays:    displays,
+			Arrangement: Arrange(displays),
+			Source:      SourceCoreGraphics,
+			Desktop:     unionBounds(displays),
+			Mirrors:     mirrors,
+		}, nil
+	}
+
+	desktop, err := c.MainBounds(ctx)
+	if err != nil {
+		return Screens{}, fmt.Errorf("failed to get desktop bounds: %w", err)
+	}
+
+	profilerOut, err := c.run.RunCommand(ctx, "system_profiler", "SPDisplaysDataType", "-json")
+	if err != nil {
+		return fallbackScreens(desktop), nil
+	}
+
+	displays, err := parseSystemProfiler(profilerOut, desktop)
+	if err != nil {
+		return fallbackScreens(desktop), nil
+	}
+
+	// If no displays detected, use fallback
+	if len(displays) == 0 {
+		displays = []Info{fallbackDisplay(desktop)}
+	}
+	return Screens{
+		Displays:    displays,
+		Arrangement: Arrange(displays),
+		Source:      SourceSystemProfiler,
+		Desktop:     desktop,
+	}, nil
+}
+
+// unionBounds returns the smallest bounds containing every display.
+func unionBounds(displays []Info) Bounds {
+	b := Bounds{Left: displays[0].Left, Top: displays[0].Top, Right: displays[0].Right, Bottom: displays[0].Bottom}
+	for _, d := range displays[1:] {
+		b.Left = min(b.Left, d.Left)
+		b.Top = min(b.Top, d.Top)
+		b.Right = max(b.Right, d.Right)
+		b.Bottom = max(b.Bottom, d.Bottom)
+	}
+	b.Width = b.Right - b.Left
+	b.Height = b.Bottom - b.Top
+	return b
+}
+
+func fallbackDisplay(desktop Bounds) Info {
+	return Info{
+		Index:   0,
+		Name:    "Main Display",
+		Left:    desktop.Left,
+		Top:     desktop.Top,
+		Right:   desktop.Right,
+		Bottom:  desktop.Bottom,
+		Width:   desktop.Width,
+		Height:  desktop.Height,
+		IsMain:  true,
+		Rotated: desktop.Height > desktop.Width,
+	}
+}
+
+func fallbackScreens(desktop Bounds) Screens {
+	return Screens{
+		Displays: []Info{fallbackDisplay(desktop)},
+		Source:   SourceDesktop,
+		Desktop:  desktop,
+		Fallback: true,
+	}
+}
+
+func parseSystemProfiler(out string, desktop Bounds) ([]Info, error) {
+	var profilerData systemProfilerData
+	if err := json.Unmarshal([]byte(out), &profilerData); err != nil {
+		return nil, err
+	}
+
+	var displays []Info
+	displayIndex := 0
+	currentX := desktop.Left // Start from the actual left edge of the virtual desktop
+
+	for _, gpu := range profilerData.SPDisplaysDataType {
+		for _, d := range gpu.Displays {
+			isMain := d.Main == "spdisplays_yes"
+
+			// Parse resolution (e.g., "3840 x 2160")
+			width := desktop.Width
+			height := desktop.Height
+			if d.Resolution != "" {
+				resParts := strings.Fields(d.Resolution)
+				if len(resParts) >= 3 {
+					if w, err := strconv.Atoi(resParts[0]); err == nil {
+						width = w
+					}
+					if h, err := strconv.Atoi(resParts[2]); err == nil {
+						height = h
+					}
+				}
+			}
+
+			// Calculate position in the virtual coordinate space
+			// Main display starts at x=0 (or desktop.Left if offset)
+			// Other displays are positioned horizontally to the right
+			left := currentX
+
+			// For vertical positioning: align displays at the top of the virtual space
+			// This accounts for menu bar and different display heights
+			top := desktop.Top
+
+			if isMain {
+				// Main display typically starts at x=0 in the virtual space
+				left = 0
+			}
+
+			displays = append(displays, Info{
+				Index:   displayIndex,
+				Name:    d.Name,
+				Left:    left,
+				Top:     top,
+				Right:   left + width,
+				Bottom:  top + height,
+				Width:   width,
+				Height:  height,
+				IsMain:  isMain,
+				Rotated: height > width,
+			})
+
+			// Move to the right for the next display
+			currentX = left + width
+			displayIndex++
+		}
+	}
+	return displays, nil
+}
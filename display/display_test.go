@@ -0,0 +1,144 @@
+package display
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+const profilerOutput = `{"SPDisplaysDataType": [{"_name": "Apple M2", "spdisplays_ndrvs": [
+  {"_name": "Built-in Retina Display", "_spdisplays_resolution": "1512 x 982 @ 120.00Hz", "spdisplays_main": "spdisplays_yes"},
+  {"_name": "DELL U2720Q", "_spdisplays_resolution": "2160 x 3840 @ 60.00Hz"}
+]}]}`
+
+// respond answers the Finder desktop script and system_profiler. The
+// CoreGraphics query fails, as it does when JXA cannot load AppKit.
+func respond(desktop, profiler string, profilerErr error) func(applescripttest.Call) (string, error) {
+	return func(c applescripttest.Call) (string, error) {
+		switch c.Kind {
+		case applescripttest.Command:
+			return profiler, profilerErr
+		case applescripttest.JXA:
+			return "", errors.New("CoreGraphics unavailable")
+		}
+		return desktop, nil
+	}
+}
+
+func TestMainBoundsScript(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs("0, 0, 3672, 3840")}
+	got, err := New(r).MainBounds(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Bounds{Left: 0, Top: 0, Right: 3672, Bottom: 3840, Width: 3672, Height: 3840}
+	if got != want {
+		t.Errorf("MainBounds = %+v, want %+v", got, want)
+	}
+	applescripttest.Golden(t, "desktop_bounds", r.Scripts()[0])
+}
+
+func TestList(t *testing.T) {
+	r := &applescripttest.Runner{Respond: respond("0,0,3672,3840", profilerOutput, nil)}
+	got, err := New(r).List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Fallback || got.Source != SourceSystemProfiler {
+		t.Errorf("Fallback, Source = %v, %q; want false, %q", got.Fallback, got.Source, SourceSystemProfiler)
+	}
+	want := []Info{
+		{Index: 0, Name: "Built-in Retina Display", Left: 0, Top: 0, Right: 1512, Bottom: 982, Width: 1512, Height: 982, IsMain: true},
+		{Index: 1, Name: "DELL U2720Q", Left: 1512, Top: 0, Right: 3672, Bottom: 3840, Width: 2160, Height: 3840, Rotated: true},
+	}
+	if !reflect.DeepEqual(got.Displays, want) {
+		t.Errorf("Displays =\n%+v\nwant\n%+v", got.Displays, want)
+	}
+
+	calls := r.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("got %d calls, want CoreGraphics, desktop and system_profiler", len(calls))
+	}
+	if c := calls[2]; c.Name != "system_profiler" || !reflect.DeepEqual(c.Args, []string{"SPDisplaysDataType", "-json"}) {
+		t.Errorf("command = %s %v, want system_profiler SPDisplaysDataType -json", c.Name, c.Args)
+	}
+}
+
+func TestListFallsBackToDesktop(t *testing.T) {
+	for name, respondFn := range map[string]func(applescripttest.Call) (string, error){
+		"profiler error": respond("0,0,1440,900", "", errors.New("not found")),
+		"invalid json":   respond("0,0,1440,900", "not json", nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := New(&applescripttest.Runner{Respond: respondFn}).List(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Fallback || len(got.Displays) != 1 {
+				t.Fatalf("got %+v, want a single fallback display", got)
+			}
+			if d := got.Displays[0]; d.Width != 1440 || d.Height != 900 || !d.IsMain {
+				t.Errorf("fallback display = %+v", d)
+			}
+		})
+	}
+}
+
+// A laptop with a rotated monitor above it and a mirror set to its right.
+const coreGraphicsOutput = `[
+	{"id": 1, "uuid": "37D8832A-2D66-02CA-B9F7-8F30A301B230", "name": "Built-in Retina Display", "x": 0, "y": 0, "w": 1512, "h": 982, "main": true, "builtin": true, "mirrored": false, "rotation": 0},
+	{"id": 2, "name": "DELL U2720Q", "x": -324, "y": -2160, "w": 2160, "h": 3840, "main": false, "builtin": false, "mirrored": false, "rotation": 90},
+	{"id": 3, "name": "", "x": 1512, "y": 0, "w": 1920, "h": 1080, "main": false, "builtin": false, "mirrored": true, "rotation": 0},
+	{"id": 4, "uuid": "9A1F03B2-5C7E-4D2A-8E61-0B4F2C9D7E13", "mirrorOf": 3, "builtin": false}
+]`
+
+func TestListCoreGraphics(t *testing.T) {
+	// Finder automation is denied: CoreGraphics must not depend on it.
+	r := &applescripttest.Runner{Respond: func(c applescripttest.Call) (string, error) {
+		if c.Kind == applescripttest.JXA {
+			return coreGraphicsOutput, nil
+		}
+		return "", errors.New("Not authorized to send Apple events to Finder. (-1743)")
+	}}
+	got, err := New(r).List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Source != SourceCoreGraphics || got.Fallback {
+		t.Errorf("Source, Fallback = %q, %v; want %q, false", got.Source, got.Fallback, SourceCoreGraphics)
+	}
+	for _, c := range r.Calls() {
+		if c.Kind != applescripttest.JXA {
+			t.Errorf("ran a %s call although CoreGraphics answered", c.Kind)
+		}
+	}
+	applescripttest.Golden(t, "coregraphics_displays", r.Scripts()[0])
+	if want := (Bounds{Left: -324, Top: -2160, Right: 3432, Bottom: 1680, Width: 3756, Height: 3840}); got.Desktop != want {
+		t.Errorf("Desktop = %+v, want union of displays %+v", got.Desktop, want)
+	}
+
+	want := []Info{
+		{Index: 0, Name: "Built-in Retina Display", Left: 0, Top: 0, Right: 1512, Bottom: 982, Width: 1512, Height: 982,
+			IsMain: true, DisplayID: 1, UUID: "37D8832A-2D66-02CA-B9F7-8F30A301B230", Builtin: true},
+		{Index: 1, Name: "DELL U2720Q", Left: -324, Top: -2160, Right: 1836, Bottom: 1680, Width: 2160, Height: 3840,
+			Rotated: true, DisplayID: 2, RotationDegrees: 90},
+		{Index: 2, Name: "Display 3", Left: 1512, Top: 0, Right: 3432, Bottom: 1080, Width: 1920, Height: 1080,
+			DisplayID: 3, Mirrored: true},
+	}
+	if !reflect.DeepEqual(got.Displays, want) {
+		t.Errorf("Displays =\n%+v\nwant\n%+v", got.Displays, want)
+	}
+	wantMirrors := []Mirror{{DisplayID: 4, UUID: "9A1F03B2-5C7E-4D2A-8E61-0B4F2C9D7E13", MirrorOf: 3}}
+	if !reflect.DeepEqual(got.Mirrors, wantMirrors) {
+		t.Errorf("Mirrors = %+v, want display 4 mirroring display 3", got.Mirrors)
+	}
+}
+
+func TestParseCoreGraphicsRejectsEmptyDisplay(t *testing.T) {
+	if _, _, err := parseCoreGraphics(`[{"id": 1, "w": 0, "h": 0}]`); err == nil {
+		t.Error("want error for a display without size")
+	}
+}
@@ -0,0 +1,86 @@
+package display
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Space describes the active Space on one display.
+//
+// There is no public API for Spaces. CGSCopyManagedDisplaySpaces (private
+// SkyLight/CoreGraphics) is what Mission Control itself uses; JXA can bind it
+// via ObjC.bindFunction without a compiled helper.
+type Space struct {
+	DisplayID  string `json:"displayId" jsonschema:"Display UUID, matching the uuid of a list_all_screens display, or 'Main' when all displays share Spaces"`
+	SpaceID    int    `json:"spaceId" jsonschema:"Managed Space ID of the active Space"`
+	SpaceUUID  string `json:"spaceUuid,omitempty" jsonschema:"UUID of the active Space (empty for the default desktop)"`
+	SpaceIndex int    `json:"spaceIndex" jsonschema:"Position of the active Space on this display, left to right in Mission Control (1-based); full-screen app Spaces count, so this can differ from the Desktop number"`
+	SpaceCount int    `json:"spaceCount" jsonschema:"Number of Spaces on this display, full-screen app Spaces included"`
+	FullScreen bool   `json:"fullScreen" jsonschema:"Whether the active Space is a full-screen app Space"`
+}
+
+type managedSpace struct {
+	ManagedSpaceID int    `json:"ManagedSpaceID"`
+	UUID           string `json:"uuid"`
+	Type           int    `json:"type"`
+}
+
+type managedDisplaySpaces struct {
+	DisplayIdentifier string         `json:"Display Identifier"`
+	CurrentSpace      managedSpace   `json:"Current Space"`
+	Spaces            []managedSpace `json:"Spaces"`
+}
+
+// spaceTypeFullScreen is the CGS space type of a full-screen app Space.
+const spaceTypeFullScreen = 4
+
+const currentSpaceScript = `
+ObjC.bindFunction("CGSMainConnectionID", ["int", []]);
+ObjC.bindFunction("CGSCopyManagedDisplaySpaces", ["id", ["int"]]);
+JSON.stringify(ObjC.deepUnwrap($.CGSCopyManagedDisplaySpaces($.CGSMainConnectionID())));
+`
+
+// CurrentSpaces returns the active Space for each display.
+func (c *Client) CurrentSpaces(ctx context.Context) ([]Space, error) {
+	out, err := c.run.RunJXA(ctx, currentSpaceScript)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Spaces: %w", err)
+	}
+
+	spaces, err := parseManagedDisplaySpaces(out)
+	if err != nil {
+		return nil, err
+	}
+	if len(spaces) == 0 {
+		return nil, fmt.Errorf("no Spaces reported by the window server")
+	}
+	return spaces, nil
+}
+
+func parseManagedDisplaySpaces(out string) ([]Space, error) {
+	var raw []managedDisplaySpaces
+	if err := json.Unmarshal([]byte(out), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse Spaces data: %w", err)
+	}
+
+	spaces := make([]Space, 0, len(raw))
+	for _, d := range raw {
+		index := 0
+		for i, sp := range d.Spaces {
+			if sp.ManagedSpaceID == d.CurrentSpace.ManagedSpaceID {
+				index = i + 1
+				break
+			}
+		}
+		spaces = append(spaces, Space{
+			DisplayID:  d.DisplayIdentifier,
+			SpaceID:    d.CurrentSpace.ManagedSpaceID,
+			SpaceUUID:  d.CurrentSpace.UUID,
+			SpaceIndex: index,
+			SpaceCount: len(d.Spaces),
+			FullScreen: d.CurrentSpace.Type == spaceTypeFullScreen,
+		})
+	}
+	return spaces, nil
+}
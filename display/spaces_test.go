@@ -0,0 +1,48 @@
+package display
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+const managedSpacesOutput = `[
+ {"Display Identifier": "37D8832A-2D66-02CA-B9F7-8F30A301B230",
+  "Current Space": {"ManagedSpaceID": 5, "uuid": "B2F6", "type": 0},
+  "Spaces": [{"ManagedSpaceID": 1, "uuid": "", "type": 0}, {"ManagedSpaceID": 5, "uuid": "B2F6", "type": 0}, {"ManagedSpaceID": 9, "uuid": "C1", "type": 4}]},
+ {"Display Identifier": "Main",
+  "Current Space": {"ManagedSpaceID": 12, "uuid": "FS", "type": 4},
+  "Spaces": [{"ManagedSpaceID": 3, "uuid": "", "type": 0}, {"ManagedSpaceID": 12, "uuid": "FS", "type": 4}]}
+]`
+
+func TestCurrentSpaces(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(managedSpacesOutput)}
+	got, err := New(r).CurrentSpaces(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Space{
+		{DisplayID: "37D8832A-2D66-02CA-B9F7-8F30A301B230", SpaceID: 5, SpaceUUID: "B2F6", SpaceIndex: 2, SpaceCount: 3},
+		{DisplayID: "Main", SpaceID: 12, SpaceUUID: "FS", SpaceIndex: 2, SpaceCount: 2, FullScreen: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CurrentSpaces =\n%+v\nwant\n%+v", got, want)
+	}
+	applescripttest.Golden(t, "current_space", r.Scripts()[0])
+}
+
+func TestCurrentSpacesErrors(t *testing.T) {
+	for name, out := range map[string]string{
+		"invalid json": "oops",
+		"empty":        "[]",
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := &applescripttest.Runner{Respond: applescripttest.Outputs(out)}
+			if _, err := New(r).CurrentSpaces(context.Background()); err == nil {
+				t.Error("want error")
+			}
+		})
+	}
+}
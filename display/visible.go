@@ -0,0 +1,89 @@
+package display
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// ScreenFrame is a display's full frame and its visible frame, the part not
+// covered by the menu bar or the Dock.
+type ScreenFrame struct {
+	Frame   Bounds `json:"frame" jsonschema:"Full display frame"`
+	Visible Bounds `json:"visible" jsonschema:"Frame excluding the menu bar and Dock"`
+}
+
+// NSScreen frames use a bottom-left origin with Y growing upwards; they are
+// flipped in Go (see toGlobal) so the script stays a plain dump.
+const screenFramesScript = `
+ObjC.import("AppKit");
+var screens = $.NSScreen.screens;
+var out = [];
+for (var i = 0; i < screens.count; i++) {
+	var s = screens.objectAtIndex(i);
+	var f = s.frame, v = s.visibleFrame;
+	out.push({
+		frame: {x: f.origin.x, y: f.origin.y, w: f.size.width, h: f.size.height},
+		visible: {x: v.origin.x, y: v.origin.y, w: v.size.width, h: v.size.height}
+	});
+}
+JSON.stringify(out);
+`
+
+type nsRect struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	W float64 `json:"w"`
+	H float64 `json:"h"`
+}
+
+// VisibleFrames returns the full and visible frame of every display, in
+// NSScreen order (the screen with the menu bar first).
+func (c *Client) VisibleFrames(ctx context.Context) ([]ScreenFrame, error) {
+	out, err := c.run.RunJXA(ctx, screenFramesScript)
+	if err != nil {
+		return nil, err
+	}
+	return parseScreenFrames(out)
+}
+
+func parseScreenFrames(out string) ([]ScreenFrame, error) {
+	var raw []struct {
+		Frame   nsRect `json:"frame"`
+		Visible nsRect `json:"visible"`
+	}
+	if err := json.Unmarshal([]byte(out), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse screen frames: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("no screens reported")
+	}
+
+	// Cocoa's origin is the bottom-left of the first (menu bar) screen.
+	mainHeight := raw[0].Frame.H
+	frames := make([]ScreenFrame, 0, len(raw))
+	for _, r := range raw {
+		frames = append(frames, ScreenFrame{
+			Frame:   toGlobal(r.Frame, mainHeight),
+			Visible: toGlobal(r.Visible, mainHeight),
+		})
+	}
+	return frames, nil
+}
+
+// toGlobal converts a Cocoa rect to top-left-origin global coordinates.
+func toGlobal(r nsRect, mainHeight float64) Bounds {
+	left := int(math.Round(r.X))
+	top := int(math.Round(mainHeight - (r.Y + r.H)))
+	width := int(math.Round(r.W))
+	height := int(math.Round(r.H))
+	return Bounds{
+		Left:   left,
+		Top:    top,
+		Right:  left + width,
+		Bottom: top + height,
+		Width:  width,
+		Height: height,
+	}
+}
@@ -0,0 +1,49 @@
+package display
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+// A 1440x900 laptop with a 25pt menu bar and a 70pt Dock, and a 2560x1440
+// display to its right whose top edge is 300pt above the laptop's.
+const screenFramesOutput = `[
+	{"frame": {"x": 0, "y": 0, "w": 1440, "h": 900}, "visible": {"x": 0, "y": 70, "w": 1440, "h": 805}},
+	{"frame": {"x": 1440, "y": -240, "w": 2560, "h": 1440}, "visible": {"x": 1440, "y": -240, "w": 2560, "h": 1415}}
+]`
+
+func TestVisibleFrames(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(screenFramesOutput)}
+	frames, err := New(r).VisibleFrames(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	applescripttest.Golden(t, "screen_frames", r.Scripts()[0])
+
+	want := []ScreenFrame{
+		{
+			Frame:   Bounds{Left: 0, Top: 0, Right: 1440, Bottom: 900, Width: 1440, Height: 900},
+			Visible: Bounds{Left: 0, Top: 25, Right: 1440, Bottom: 830, Width: 1440, Height: 805},
+		},
+		{
+			Frame:   Bounds{Left: 1440, Top: -300, Right: 4000, Bottom: 1140, Width: 2560, Height: 1440},
+			Visible: Bounds{Left: 1440, Top: -275, Right: 4000, Bottom: 1140, Width: 2560, Height: 1415},
+		},
+	}
+	if len(frames) != len(want) {
+		t.Fatalf("got %d frames, want %d", len(frames), len(want))
+	}
+	for i := range want {
+		if frames[i] != want[i] {
+			t.Errorf("frame %d = %+v, want %+v", i, frames[i], want[i])
+		}
+	}
+}
+
+func TestParseScreenFramesEmpty(t *testing.T) {
+	if _, err := parseScreenFrames("[]"); err == nil {
+		t.Error("want error for no screens")
+	}
+}
@@ -0,0 +1,33 @@
+package layout
+
+import (
+	"fmt"
+	"math"
+)
+
+// CellMetrics describes the character grid of a terminal or editor: the
+// size of one cell and the pixels around the grid (title bar, tab bar,
+// padding, scroll bar) that do not hold cells.
+type CellMetrics struct {
+	CellWidth  float64 `json:"cellWidth" jsonschema:"Width of one character cell in pixels"`
+	CellHeight float64 `json:"cellHeight" jsonschema:"Height of one character cell (line height) in pixels"`
+	PaddingX   int     `json:"paddingX,omitempty" jsonschema:"Horizontal pixels outside the grid (padding, scroll bar)"`
+	PaddingY   int     `json:"paddingY,omitempty" jsonschema:"Vertical pixels outside the grid (title bar, tab bar, padding)"`
+}
+
+// CellsToPixels returns the window size that holds exactly columns x rows
+// cells. Sizes round up so the last column and row are never cut off.
+func CellsToPixels(columns, rows int, m CellMetrics) (width, height int, err error) {
+	if columns <= 0 || rows <= 0 {
+		return 0, 0, fmt.Errorf("columns and rows must be > 0")
+	}
+	if m.CellWidth <= 0 || m.CellHeight <= 0 {
+		return 0, 0, fmt.Errorf("cellWidth and cellHeight must be > 0")
+	}
+	if m.PaddingX < 0 || m.PaddingY < 0 {
+		return 0, 0, fmt.Errorf("padding must not be negative")
+	}
+	width = int(math.Ceil(float64(columns)*m.CellWidth)) + m.PaddingX
+	height = int(math.Ceil(float64(rows)*m.CellHeight)) + m.PaddingY
+	return width, height, nil
+}
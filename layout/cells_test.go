@@ -0,0 +1,46 @@
+package layout
+
+import "testing"
+
+func TestCellsToPixels(t *testing.T) {
+	tests := []struct {
+		name          string
+		columns, rows int
+		m             CellMetrics
+		w, h          int
+	}{
+		{"whole cells", 80, 24, CellMetrics{CellWidth: 7, CellHeight: 14}, 560, 336},
+		{"fractional cells round up", 120, 40, CellMetrics{CellWidth: 7.22, CellHeight: 16.5}, 867, 660},
+		{"padding", 100, 30, CellMetrics{CellWidth: 8, CellHeight: 17, PaddingX: 10, PaddingY: 38}, 810, 548},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h, err := CellsToPixels(tt.columns, tt.rows, tt.m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if w != tt.w || h != tt.h {
+				t.Errorf("got %dx%d, want %dx%d", w, h, tt.w, tt.h)
+			}
+		})
+	}
+}
+
+func TestCellsToPixelsErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		columns, rows int
+		m             CellMetrics
+	}{
+		{"no columns", 0, 40, CellMetrics{CellWidth: 7, CellHeight: 14}},
+		{"no metrics", 120, 40, CellMetrics{}},
+		{"negative padding", 120, 40, CellMetrics{CellWidth: 7, CellHeight: 14, PaddingY: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := CellsToPixels(tt.columns, tt.rows, tt.m); err == nil {
+				t.Error("want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Package layout computes window frames: positioning presets relative to a
+// display, anchored / aspect-preserving resizes, and multi-window layout
+// plans. It performs no I/O.
+package layout
+
+import (
+	"fmt"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/display"
+)
+
+// CalculateBounds returns the window frame for a positioning preset on
+// screen. The offsets and size are only used (and then required) for the
+// "custom" preset, where offsets are relative to the screen's top-left.
+func CalculateBounds(screen display.Info, position string, xOffset, yOffset, width, height *int) (x, y, w, h int, err error) {
+	switch position {
+	case "center":
+		w = screen.Width / 2
+		h = screen.Height / 2
+		x = screen.Left + (screen.Width-w)/2
+		y = screen.Top + (screen.Height-h)/2
+	case "maximize":
+		x = screen.Left
+		y = screen.Top
+		w = screen.Width
+		h = screen.Height
+	case "left-half":
+		x = screen.Left
+		y = screen.Top
+		w = screen.Width / 2
+		h = screen.Height
+	case "right-half":
+		x = screen.Left + screen.Width/2
+		y = screen.Top
+		w = screen.Width / 2
+		h = screen.Height
+	case "top-half":
+		x = screen.Left
+		y = screen.Top
+		w = screen.Width
+		h = screen.Height / 2
+	case "bottom-half":
+		x = screen.Left
+		y = screen.Top + screen.Height/2
+		w = screen.Width
+		h = screen.Height / 2
+	case "custom":
+		if xOffset == nil || yOffset == nil || width == nil || height == nil {
+			return 0, 0, 0, 0, fmt.Errorf("custom position requires xOffset, yOffset, width, and height")
+		}
+		x = screen.Left + *xOffset
+		y = screen.Top + *yOffset
+		w = *width
+		h = *height
+	default:
+		return 0, 0, 0, 0, fmt.Errorf("invalid position preset: %q (valid: center, maximize, left-half, right-half, top-half, bottom-half, custom)", position)
+	}
+	return x, y, w, h, nil
+}
+
+// VisibleArea narrows screen to its visible frame from frames (below the
+// menu bar, beside the Dock), matched on the full frame, so presets fill the
+// area macOS lets windows use, like the zoom button. A screen without a
+// matching frame is returned unchanged.
+func VisibleArea(screen display.Info, frames []display.ScreenFrame) display.Info {
+	for _, f := range frames {
+		if f.Frame.Left != screen.Left || f.Frame.Top != screen.Top ||
+			f.Frame.Width != screen.Width || f.Frame.Height != screen.Height {
+			continue
+		}
+		v := f.Visible
+		if v.Width <= 0 || v.Height <= 0 {
+			break
+		}
+		screen.Left, screen.Top, screen.Right, screen.Bottom = v.Left, v.Top, v.Right, v.Bottom
+		screen.Width, screen.Height = v.Width, v.Height
+		break
+	}
+	return screen
+}
+
+// ResizeWithAnchor computes the new frame for a window currently at
+// (x, y, w, h) so that the anchor point stays where it is. Anchors are
+// "top-left" (the default when empty), "top-right", "bottom-left",
+// "bottom-right" and "center".
+//
+// With preserve set, the result is the largest size with the current aspect
+// ratio that fits in width x height; either dimension may then be 0 to
+// derive it from the other.
+func ResizeWithAnchor(x, y, w, h, width, height int, anchor string, preserve bool) (nx, ny, nw, nh int, err error) {
+	if width < 0 || height < 0 {
+		return 0, 0, 0, 0, fmt.Errorf("width and height must not be negative")
+	}
+
+	nw, nh = width, height
+	if preserve {
+		if w <= 0 || h <= 0 {
+			return 0, 0, 0, 0, fmt.Errorf("current window size %dx%d has no aspect ratio", w, h)
+		}
+		ratio := float64(w) / float64(h)
+		switch {
+		case width > 0 && height > 0:
+			nw = width
+			nh = int(float64(width)/ratio + 0.5)
+			if nh > height {
+				nh = height
+				nw = int(float64(height)*ratio + 0.5)
+			}
+		case width > 0:
+			nh = int(float64(width)/ratio + 0.5)
+		case height > 0:
+			nw = int(float64(height)*ratio + 0.5)
+		default:
+			return 0, 0, 0, 0, fmt.Errorf("width or height is required")
+		}
+	}
+	if nw <= 0 || nh <= 0 {
+		return 0, 0, 0, 0, fmt.Errorf("width and height must be > 0 (or set preserveAspectRatio to derive one)")
+	}
+
+	switch anchor {
+	case "", "top-left":
+		nx, ny = x, y
+	case "top-right":
+		nx, ny = x+w-nw, y
+	case "bottom-left":
+		nx, ny = x, y+h-nh
+	case "bottom-right":
+		nx, ny = x+w-nw, y+h-nh
+	case "center":
+		nx, ny = x+(w-nw)/2, y+(h-nh)/2
+	default:
+		return 0, 0, 0, 0, fmt.Errorf("invalid anchor: %q (valid: top-left, top-right, bottom-left, bottom-right, center)", anchor)
+	}
+	return nx, ny, nw, nh, nil
+}
@@ -0,0 +1,106 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/display"
+)
+
+func intp(v int) *int { return &v }
+
+func TestCalculateBounds(t *testing.T) {
+	screen := display.Info{Left: 1440, Top: 0, Width: 2560, Height: 1440}
+	tests := []struct {
+		position   string
+		x, y, w, h int
+	}{
+		{"center", 2080, 360, 1280, 720},
+		{"maximize", 1440, 0, 2560, 1440},
+		{"left-half", 1440, 0, 1280, 1440},
+		{"right-half", 2720, 0, 1280, 1440},
+		{"top-half", 1440, 0, 2560, 720},
+		{"bottom-half", 1440, 720, 2560, 720},
+	}
+	for _, tt := range tests {
+		t.Run(tt.position, func(t *testing.T) {
+			x, y, w, h, err := CalculateBounds(screen, tt.position, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if x != tt.x || y != tt.y || w != tt.w || h != tt.h {
+				t.Errorf("got (%d,%d) %dx%d, want (%d,%d) %dx%d", x, y, w, h, tt.x, tt.y, tt.w, tt.h)
+			}
+		})
+	}
+}
+
+func TestCalculateBoundsCustom(t *testing.T) {
+	screen := display.Info{Left: -1920, Top: -200, Width: 1920, Height: 1080}
+	x, y, w, h, err := CalculateBounds(screen, "custom", intp(100), intp(50), intp(800), intp(600))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x != -1820 || y != -150 || w != 800 || h != 600 {
+		t.Errorf("got (%d,%d) %dx%d, want (-1820,-150) 800x600", x, y, w, h)
+	}
+
+	if _, _, _, _, err := CalculateBounds(screen, "custom", intp(0), nil, intp(1), intp(1)); err == nil {
+		t.Error("custom without yOffset: want error")
+	}
+	if _, _, _, _, err := CalculateBounds(screen, "diagonal", nil, nil, nil, nil); err == nil {
+		t.Error("unknown preset: want error")
+	}
+}
+
+func TestResizeWithAnchor(t *testing.T) {
+	// Current window: (100,100) 1600x900, a 16:9 video window.
+	tests := []struct {
+		name          string
+		width, height int
+		anchor        string
+		preserve      bool
+		x, y, w, h    int
+	}{
+		{"top-left default", 800, 600, "", false, 100, 100, 800, 600},
+		{"top-right", 800, 600, "top-right", false, 900, 100, 800, 600},
+		{"bottom-left", 800, 600, "bottom-left", false, 100, 400, 800, 600},
+		{"bottom-right", 800, 600, "bottom-right", false, 900, 400, 800, 600},
+		{"center", 800, 600, "center", false, 500, 250, 800, 600},
+		{"aspect from width", 1280, 0, "", true, 100, 100, 1280, 720},
+		{"aspect from height", 0, 450, "", true, 100, 100, 800, 450},
+		{"aspect fits box by height", 1280, 540, "center", true, 420, 280, 960, 540},
+		{"aspect fits box by width", 640, 1000, "", true, 100, 100, 640, 360},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y, w, h, err := ResizeWithAnchor(100, 100, 1600, 900, tt.width, tt.height, tt.anchor, tt.preserve)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if x != tt.x || y != tt.y || w != tt.w || h != tt.h {
+				t.Errorf("got (%d,%d) %dx%d, want (%d,%d) %dx%d", x, y, w, h, tt.x, tt.y, tt.w, tt.h)
+			}
+		})
+	}
+}
+
+func TestResizeWithAnchorErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		anchor        string
+		preserve      bool
+	}{
+		{"missing height", 800, 0, "", false},
+		{"negative", -1, 100, "", false},
+		{"no dimensions with aspect", 0, 0, "", true},
+		{"bad anchor", 800, 600, "middle", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, _, err := ResizeWithAnchor(0, 0, 1600, 900, tt.width, tt.height, tt.anchor, tt.preserve); err == nil {
+				t.Error("want error")
+			}
+		})
+	}
+}
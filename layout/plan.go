@@ -0,0 +1,98 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/display"
+)
+
+// Rect is a window frame in global coordinates.
+type Rect struct {
+	X      int `json:"x" jsonschema:"X position in pixels"`
+	Y      int `json:"y" jsonschema:"Y position in pixels"`
+	Width  int `json:"width" jsonschema:"Width in pixels"`
+	Height int `json:"height" jsonschema:"Height in pixels"`
+}
+
+// Placement is one entry of a target arrangement: where a window should go.
+type Placement struct {
+	AppName     string `json:"appName" jsonschema:"Name of the application"`
+	WindowIndex int    `json:"windowIndex,omitempty" jsonschema:"Window index (1-based, 1 = frontmost window; defaults to 1)"`
+	ScreenIndex int    `json:"screenIndex" jsonschema:"Target screen index (0 = main display)"`
+	Position    string `json:"position" jsonschema:"Positioning preset: 'center', 'maximize', 'left-half', 'right-half', 'top-half', 'bottom-half', or 'custom'"`
+	XOffset     *int   `json:"xOffset,omitempty" jsonschema:"X offset from screen left (pixels, for custom position)"`
+	YOffset     *int   `json:"yOffset,omitempty" jsonschema:"Y offset from screen top (pixels, for custom position)"`
+	Width       *int   `json:"width,omitempty" jsonschema:"Window width (pixels, for custom position)"`
+	Height      *int   `json:"height,omitempty" jsonschema:"Window height (pixels, for custom position)"`
+}
+
+// Operation is one concrete step of a layout plan: move window WindowIndex of
+// AppName from From to To.
+type Operation struct {
+	Step        int    `json:"step" jsonschema:"1-based position in the plan"`
+	AppName     string `json:"appName" jsonschema:"Name of the application"`
+	WindowIndex int    `json:"windowIndex" jsonschema:"Window index (1-based) at planning time"`
+	ScreenIndex int    `json:"screenIndex" jsonschema:"Target screen index"`
+	ScreenName  string `json:"screenName,omitempty" jsonschema:"Target screen name"`
+	Position    string `json:"position" jsonschema:"Preset the target frame was computed from"`
+	From        Rect   `json:"from" jsonschema:"Frame at planning time"`
+	To          Rect   `json:"to" jsonschema:"Frame to apply"`
+}
+
+// Plan resolves placements into operations. current holds each placement's
+// window frame at planning time, in the same order. Presets fill each
+// screen's visible frame from frames (see VisibleArea); custom offsets stay
+// relative to the full display. frames may be nil. A window may only be
+// placed once per plan.
+func Plan(screens []display.Info, frames []display.ScreenFrame, placements []Placement, current []Rect) ([]Operation, error) {
+	if len(placements) == 0 {
+		return nil, fmt.Errorf("at least one placement is required")
+	}
+	if len(current) != len(placements) {
+		return nil, fmt.Errorf("got %d current frames for %d placements", len(current), len(placements))
+	}
+
+	seen := make(map[string]int)
+	ops := make([]Operation, 0, len(placements))
+	for i, p := range placements {
+		if p.AppName == "" {
+			return nil, fmt.Errorf("placement %d: appName is required", i+1)
+		}
+		if p.WindowIndex == 0 {
+			p.WindowIndex = 1
+		}
+		if p.WindowIndex < 1 {
+			return nil, fmt.Errorf("placement %d: windowIndex must be >= 1", i+1)
+		}
+		key := fmt.Sprintf("%s\x00%d", p.AppName, p.WindowIndex)
+		if prev, ok := seen[key]; ok {
+			return nil, fmt.Errorf("placement %d: '%s' window %d is already placed by placement %d", i+1, p.AppName, p.WindowIndex, prev)
+		}
+		seen[key] = i + 1
+
+		if p.ScreenIndex < 0 || p.ScreenIndex >= len(screens) {
+			return nil, fmt.Errorf("placement %d: invalid screen index %d (available: 0-%d)", i+1, p.ScreenIndex, len(screens)-1)
+		}
+		screen := screens[p.ScreenIndex]
+		area := screen
+		if p.Position != "custom" {
+			area = VisibleArea(screen, frames)
+		}
+		x, y, w, h, err := CalculateBounds(area, p.Position, p.XOffset, p.YOffset, p.Width, p.Height)
+		if err != nil {
+			return nil, fmt.Errorf("placement %d: %w", i+1, err)
+		}
+
+		ops = append(ops, Operation{
+			Step:        i + 1,
+			AppName:     p.AppName,
+			WindowIndex: p.WindowIndex,
+			ScreenIndex: p.ScreenIndex,
+			ScreenName:  screen.Name,
+			Position:    p.Position,
+			From:        current[i],
+			To:          Rect{X: x, Y: y, Width: w, Height: h},
+		})
+	}
+	return ops, nil
+}
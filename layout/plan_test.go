@@ -0,0 +1,87 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/display"
+)
+
+var planScreens = []display.Info{
+	{Index: 0, Name: "Built-in", Left: 0, Top: 0, Width: 1440, Height: 900},
+	{Index: 1, Name: "External", Left: 1440, Top: 0, Width: 2560, Height: 1440},
+}
+
+func TestPlan(t *testing.T) {
+	placements := []Placement{
+		{AppName: "Safari", Position: "left-half"},
+		{AppName: "Terminal", WindowIndex: 2, ScreenIndex: 1, Position: "maximize"},
+	}
+	current := []Rect{{100, 100, 800, 600}, {0, 25, 640, 480}}
+
+	ops, err := Plan(planScreens, nil, placements, current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Operation{
+		{Step: 1, AppName: "Safari", WindowIndex: 1, ScreenIndex: 0, ScreenName: "Built-in", Position: "left-half",
+			From: Rect{100, 100, 800, 600}, To: Rect{0, 0, 720, 900}},
+		{Step: 2, AppName: "Terminal", WindowIndex: 2, ScreenIndex: 1, ScreenName: "External", Position: "maximize",
+			From: Rect{0, 25, 640, 480}, To: Rect{1440, 0, 2560, 1440}},
+	}
+	if len(ops) != len(want) {
+		t.Fatalf("got %d operations, want %d", len(ops), len(want))
+	}
+	for i := range want {
+		if ops[i] != want[i] {
+			t.Errorf("op %d = %+v, want %+v", i, ops[i], want[i])
+		}
+	}
+}
+
+func TestPlanErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		placements []Placement
+		want       string
+	}{
+		{"empty", nil, "at least one placement"},
+		{"no app", []Placement{{Position: "center"}}, "placement 1: appName is required"},
+		{"bad screen", []Placement{{AppName: "Safari", ScreenIndex: 2, Position: "center"}}, "invalid screen index 2"},
+		{"bad preset", []Placement{{AppName: "Safari", Position: "corner"}}, "invalid position preset"},
+		{"duplicate", []Placement{
+			{AppName: "Safari", Position: "left-half"},
+			{AppName: "Safari", WindowIndex: 1, Position: "right-half"},
+		}, "already placed by placement 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Plan(planScreens, nil, tt.placements, make([]Rect, len(tt.placements)))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanPresetsFillVisibleFrame(t *testing.T) {
+	frames := []display.ScreenFrame{{
+		Frame:   display.Bounds{Left: 0, Top: 0, Right: 1440, Bottom: 900, Width: 1440, Height: 900},
+		Visible: display.Bounds{Left: 0, Top: 25, Right: 1440, Bottom: 830, Width: 1440, Height: 805},
+	}}
+	x, y, w, h := 10, 10, 400, 300
+	placements := []Placement{
+		{AppName: "Safari", Position: "maximize"},
+		{AppName: "Terminal", Position: "custom", XOffset: &x, YOffset: &y, Width: &w, Height: &h},
+	}
+	ops, err := Plan(planScreens, frames, placements, make([]Rect, len(placements)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Rect{0, 25, 1440, 805}); ops[0].To != want {
+		t.Errorf("maximize = %+v, want visible frame %+v", ops[0].To, want)
+	}
+	if want := (Rect{10, 10, 400, 300}); ops[1].To != want {
+		t.Errorf("custom = %+v, want offsets from the full display %+v", ops[1].To, want)
+	}
+}
@@ -0,0 +1,80 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/display"
+)
+
+// CheckPlacement returns warnings for placing a window at r: parts that end
+// up off every display, parts under the menu bar or Dock, a title bar that
+// cannot be grabbed, and sizes below the app's known minimum (0 when
+// unknown). It returns nil when nothing looks wrong.
+func CheckPlacement(r Rect, screens []display.ScreenFrame, minWidth, minHeight int) []string {
+	if r.Width <= 0 || r.Height <= 0 {
+		return []string{fmt.Sprintf("size %dx%d is not positive", r.Width, r.Height)}
+	}
+
+	var warnings []string
+	area := r.Width * r.Height
+	var onScreen, visible int
+	displays := 0
+	for _, s := range screens {
+		a := overlap(r, s.Frame)
+		if a > 0 {
+			displays++
+		}
+		onScreen += a
+		visible += overlap(r, s.Visible)
+	}
+
+	if off := percent(area-onScreen, area); off > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d%% of the window will be off-screen", off))
+	}
+	if hidden := percent(onScreen-visible, area); hidden > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d%% of the window will be under the menu bar or Dock", hidden))
+	}
+	if displays > 1 {
+		warnings = append(warnings, fmt.Sprintf("the window will span %d displays", displays))
+	}
+
+	// The title bar is the only place users can drag a window from.
+	titleBar := Rect{X: r.X, Y: r.Y, Width: r.Width, Height: min(titleBarHeight, r.Height)}
+	grabbable := 0
+	for _, s := range screens {
+		grabbable += overlap(titleBar, s.Visible)
+	}
+	if onScreen > 0 && grabbable == 0 {
+		warnings = append(warnings, "the title bar will be outside the visible area, so the window cannot be dragged back")
+	}
+
+	if minWidth > 0 && r.Width < minWidth {
+		warnings = append(warnings, fmt.Sprintf("width %d is below the app minimum of %d; the window will be wider", r.Width, minWidth))
+	}
+	if minHeight > 0 && r.Height < minHeight {
+		warnings = append(warnings, fmt.Sprintf("height %d is below the app minimum of %d; the window will be taller", r.Height, minHeight))
+	}
+	return warnings
+}
+
+// titleBarHeight is the height of a standard macOS title bar in points.
+const titleBarHeight = 28
+
+// overlap returns the area of the intersection of r and b.
+func overlap(r Rect, b display.Bounds) int {
+	w := min(r.X+r.Width, b.Right) - max(r.X, b.Left)
+	h := min(r.Y+r.Height, b.Bottom) - max(r.Y, b.Top)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
+
+// percent returns part/total as a whole percentage, rounding any non-zero
+// part up to at least 1%.
+func percent(part, total int) int {
+	if part <= 0 {
+		return 0
+	}
+	return max(1, part*100/total)
+}
@@ -0,0 +1,79 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/display"
+)
+
+// A 1440x900 display with a 25px menu bar and 70px Dock, and a 1920x1080
+// display to its right.
+var checkScreens = []display.ScreenFrame{
+	{
+		Frame:   display.Bounds{Left: 0, Top: 0, Right: 1440, Bottom: 900, Width: 1440, Height: 900},
+		Visible: display.Bounds{Left: 0, Top: 25, Right: 1440, Bottom: 830, Width: 1440, Height: 805},
+	},
+	{
+		Frame:   display.Bounds{Left: 1440, Top: 0, Right: 3360, Bottom: 1080, Width: 1920, Height: 1080},
+		Visible: display.Bounds{Left: 1440, Top: 25, Right: 3360, Bottom: 1080, Width: 1920, Height: 1055},
+	},
+}
+
+func TestCheckPlacement(t *testing.T) {
+	tests := []struct {
+		name       string
+		r          Rect
+		minW, minH int
+		want       []string
+	}{
+		{"fits", Rect{100, 100, 800, 600}, 0, 0, nil},
+		{"off left edge", Rect{-300, 100, 1000, 600}, 0, 0, []string{
+			"30% of the window will be off-screen",
+		}},
+		{"under dock", Rect{0, 25, 1000, 875}, 0, 0, []string{
+			"8% of the window will be under the menu bar or Dock",
+		}},
+		{"spans displays", Rect{1040, 100, 800, 600}, 0, 0, []string{
+			"the window will span 2 displays",
+		}},
+		{"title bar hidden", Rect{100, 0, 800, 20}, 0, 0, []string{
+			"100% of the window will be under the menu bar or Dock",
+			"the title bar will be outside the visible area, so the window cannot be dragged back",
+		}},
+		{"below minimum", Rect{100, 100, 300, 200}, 500, 0, []string{
+			"width 300 is below the app minimum of 500; the window will be wider",
+		}},
+		{"empty", Rect{0, 0, 0, 100}, 0, 0, []string{"size 0x100 is not positive"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckPlacement(tt.r, checkScreens, tt.minW, tt.minH)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckPlacement = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPresetsInVisibleAreaPassCheck(t *testing.T) {
+	screen := display.Info{Name: "Built-in", Left: 0, Top: 0, Right: 1440, Bottom: 900, Width: 1440, Height: 900, IsMain: true}
+	area := VisibleArea(screen, checkScreens)
+	if area.Top != 25 || area.Height != 805 {
+		t.Fatalf("VisibleArea = %+v, want visible frame below the menu bar and above the Dock", area)
+	}
+	for _, preset := range []string{"center", "maximize", "left-half", "right-half", "top-half", "bottom-half"} {
+		x, y, w, h, err := CalculateBounds(area, preset, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := CheckPlacement(Rect{x, y, w, h}, checkScreens, 0, 0); got != nil {
+			t.Errorf("%s: warnings %q for the server's own preset", preset, got)
+		}
+	}
+
+	other := display.Info{Left: -1920, Top: 0, Right: 0, Bottom: 1080, Width: 1920, Height: 1080}
+	if got := VisibleArea(other, checkScreens); got != other {
+		t.Errorf("VisibleArea without a matching frame = %+v, want the screen unchanged", got)
+	}
+}
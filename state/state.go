@@ -0,0 +1,62 @@
+// Package state keeps server state that belongs to one MCP client session,
+// such as restore frames and window watches.
+//
+// With the stdio transport there is a single client, but over HTTP several
+// clients share one server process. Keeping their state in separate values
+// stops one client's restore_window from undoing another's moves, or
+// unwatch_window from cancelling another client's watch.
+package state
+
+import (
+	"sort"
+	"sync"
+)
+
+// Sessions holds one *T per session ID, created on first use. It is safe
+// for concurrent use; T must synchronize its own fields.
+type Sessions[T any] struct {
+	newState func() *T
+
+	mu       sync.Mutex
+	sessions map[string]*T
+}
+
+// New returns an empty Sessions that creates state with newState.
+func New[T any](newState func() *T) *Sessions[T] {
+	return &Sessions[T]{newState: newState, sessions: make(map[string]*T)}
+}
+
+// Get returns the state of session id, creating it if needed. created
+// reports whether this call created it, so callers can arrange cleanup once
+// per session.
+func (s *Sessions[T]) Get(id string) (st *T, created bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if st, ok := s.sessions[id]; ok {
+		return st, false
+	}
+	st = s.newState()
+	s.sessions[id] = st
+	return st, true
+}
+
+// Delete forgets session id and returns its state for cleanup.
+func (s *Sessions[T]) Delete(id string) (*T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.sessions[id]
+	delete(s.sessions, id)
+	return st, ok
+}
+
+// IDs returns the IDs of sessions with state, sorted.
+func (s *Sessions[T]) IDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.sessions))
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,63 @@
+package state
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func TestSessionsIsolated(t *testing.T) {
+	s := New(func() *counter { return &counter{} })
+
+	a, created := s.Get("a")
+	if !created {
+		t.Error("first Get did not report creation")
+	}
+	a.n = 1
+	if again, created := s.Get("a"); created || again != a {
+		t.Error("second Get returned new state")
+	}
+	if b, _ := s.Get("b"); b.n != 0 {
+		t.Errorf("session b sees n = %d from session a", b.n)
+	}
+
+	if ids := s.IDs(); !reflect.DeepEqual(ids, []string{"a", "b"}) {
+		t.Errorf("IDs = %v, want [a b]", ids)
+	}
+	if st, ok := s.Delete("a"); !ok || st != a {
+		t.Error("Delete did not return session a's state")
+	}
+	if _, created := s.Get("a"); !created {
+		t.Error("Get after Delete reused the old state")
+	}
+}
+
+func TestSessionsConcurrent(t *testing.T) {
+	s := New(func() *counter { return &counter{} })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, _ := s.Get(strconv.Itoa(i % 4))
+			c.mu.Lock()
+			c.n++
+			c.mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, id := range s.IDs() {
+		c, _ := s.Get(id)
+		if c.n != 25 {
+			t.Errorf("session %s: n = %d, want 25", id, c.n)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package windowmgr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// cellScripts maps terminal apps whose scripting dictionary exposes a
+// window's size in character cells to the script that sets it. Sizing
+// through the app snaps to whole cells, which pixel sizing cannot.
+var cellScripts = map[string]string{
+	// Terminal.app: columns/rows belong to the window's selected tab.
+	"terminal": `
+if application "Terminal" is not running then
+	error "Application 'Terminal' is not running."
+end if
+tell application "Terminal"
+	if (count of windows) < %[1]d then
+		error "Application 'Terminal' does not have window %[1]d."
+	end if
+	set number of columns of selected tab of window %[1]d to %[2]d
+	set number of rows of selected tab of window %[1]d to %[3]d
+end tell
+`,
+	// iTerm2: columns/rows belong to the window's current session.
+	"iterm2": `
+if application "iTerm2" is not running then
+	error "Application 'iTerm2' is not running."
+end if
+tell application "iTerm2"
+	if (count of windows) < %[1]d then
+		error "Application 'iTerm2' does not have window %[1]d."
+	end if
+	tell current session of window %[1]d
+		set columns to %[2]d
+		set rows to %[3]d
+	end tell
+end tell
+`,
+}
+
+func cellScriptFor(appName string) (string, bool) {
+	name := strings.ToLower(appName)
+	if name == "iterm" {
+		name = "iterm2"
+	}
+	s, ok := cellScripts[name]
+	return s, ok
+}
+
+// SupportsNativeCells reports whether appName can be sized in character
+// cells through its own scripting (Terminal.app, iTerm2).
+func SupportsNativeCells(appName string) bool {
+	_, ok := cellScriptFor(appName)
+	return ok
+}
+
+// SetTerminalCells resizes window windowIndex of a terminal app to columns x
+// rows character cells. The window keeps its top-left corner. Only apps for
+// which SupportsNativeCells is true are supported.
+func (c *Client) SetTerminalCells(ctx context.Context, appName string, windowIndex, columns, rows int) error {
+	script, ok := cellScriptFor(appName)
+	if !ok {
+		return fmt.Errorf("'%s' cannot be sized in cells natively (supported: Terminal, iTerm2)", appName)
+	}
+	if windowIndex < 1 {
+		return fmt.Errorf("windowIndex must be >= 1")
+	}
+	if columns <= 0 || rows <= 0 {
+		return fmt.Errorf("columns and rows must be > 0")
+	}
+
+	_, err := c.scripts.RunAppleScript(ctx, fmt.Sprintf(script, windowIndex, columns, rows))
+	return err
+}
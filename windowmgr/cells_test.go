@@ -0,0 +1,51 @@
+package windowmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+func TestSetTerminalCellsScripts(t *testing.T) {
+	tests := []struct {
+		app    string
+		golden string
+	}{
+		{"Terminal", "terminal_cells"},
+		{"iTerm2", "iterm2_cells"},
+		{"iTerm", "iterm2_cells"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.app, func(t *testing.T) {
+			r := &applescripttest.Runner{}
+			if err := New(r).SetTerminalCells(context.Background(), tt.app, 2, 120, 40); err != nil {
+				t.Fatal(err)
+			}
+			applescripttest.Golden(t, tt.golden, r.Scripts()[0])
+		})
+	}
+}
+
+func TestSetTerminalCellsValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		app           string
+		columns, rows int
+	}{
+		{"unsupported app", "Alacritty", 120, 40},
+		{"zero columns", "Terminal", 0, 40},
+		{"negative rows", "iTerm2", 120, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &applescripttest.Runner{}
+			if err := New(r).SetTerminalCells(context.Background(), tt.app, 1, tt.columns, tt.rows); err == nil {
+				t.Error("want error")
+			}
+			if n := len(r.Calls()); n != 0 {
+				t.Errorf("ran %d scripts, want 0", n)
+			}
+		})
+	}
+}
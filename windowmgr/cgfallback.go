@@ -0,0 +1,74 @@
+package windowmgr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// The FromCG queries answer read-only questions from the CoreGraphics window
+// list, which needs no Automation or Accessibility permission. They serve as
+// a fallback when System Events is blocked. Titles are empty without Screen
+// Recording permission, and window indices follow the window server's
+// front-to-back order, which usually but not always matches System Events.
+
+// ListAllFromCG returns all on-screen windows, front to back.
+func (c *Client) ListAllFromCG(ctx context.Context) ([]WindowInfo, error) {
+	cg, err := c.ListCGWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	windows := make([]WindowInfo, 0, len(cg))
+	for _, w := range cg {
+		windows = append(windows, WindowInfo{
+			AppName:     w.Owner,
+			WindowTitle: w.Name,
+			X:           int(w.Bounds.X),
+			Y:           int(w.Bounds.Y),
+			Width:       int(w.Bounds.Width),
+			Height:      int(w.Bounds.Height),
+		})
+	}
+	return windows, nil
+}
+
+// AppWindowsFromCG returns appName's on-screen windows, front to back.
+func (c *Client) AppWindowsFromCG(ctx context.Context, appName string) ([]AppWindowInfo, error) {
+	if appName == "" {
+		return nil, fmt.Errorf("appName is required")
+	}
+	cg, err := c.ListCGWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []AppWindowInfo
+	for _, w := range cg {
+		if !strings.EqualFold(w.Owner, appName) {
+			continue
+		}
+		windows = append(windows, AppWindowInfo{
+			Title:  w.Name,
+			Index:  len(windows) + 1,
+			X:      int(w.Bounds.X),
+			Y:      int(w.Bounds.Y),
+			Width:  int(w.Bounds.Width),
+			Height: int(w.Bounds.Height),
+		})
+	}
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("application '%s' has no on-screen windows", appName)
+	}
+	return windows, nil
+}
+
+// FrontGeometryFromCG returns the frame of appName's frontmost on-screen
+// window.
+func (c *Client) FrontGeometryFromCG(ctx context.Context, appName string) (Geometry, error) {
+	windows, err := c.AppWindowsFromCG(ctx, appName)
+	if err != nil {
+		return Geometry{}, err
+	}
+	w := windows[0]
+	return Geometry{AppName: appName, X: w.X, Y: w.Y, Width: w.Width, Height: w.Height}, nil
+}
@@ -0,0 +1,57 @@
+package windowmgr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+const fallbackCGOutput = `[
+	{"kCGWindowNumber": 10, "kCGWindowOwnerName": "Safari", "kCGWindowName": "", "kCGWindowLayer": 0, "kCGWindowAlpha": 1, "kCGWindowBounds": {"X": 0, "Y": 25, "Width": 700, "Height": 875}},
+	{"kCGWindowNumber": 11, "kCGWindowOwnerName": "Terminal", "kCGWindowName": "zsh", "kCGWindowLayer": 0, "kCGWindowAlpha": 1, "kCGWindowBounds": {"X": 700, "Y": 25, "Width": 740, "Height": 875}},
+	{"kCGWindowNumber": 12, "kCGWindowOwnerName": "Safari", "kCGWindowName": "", "kCGWindowLayer": 0, "kCGWindowAlpha": 1, "kCGWindowBounds": {"X": 100, "Y": 100, "Width": 800, "Height": 600}}
+]`
+
+func TestAppWindowsFromCG(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(fallbackCGOutput)}
+	got, err := New(r).AppWindowsFromCG(context.Background(), "safari")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []AppWindowInfo{
+		{Index: 1, X: 0, Y: 25, Width: 700, Height: 875},
+		{Index: 2, X: 100, Y: 100, Width: 800, Height: 600},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AppWindowsFromCG = %+v, want %+v", got, want)
+	}
+}
+
+func TestFrontGeometryFromCG(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(fallbackCGOutput)}
+	got, err := New(r).FrontGeometryFromCG(context.Background(), "Terminal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Geometry{AppName: "Terminal", X: 700, Y: 25, Width: 740, Height: 875}); got != want {
+		t.Errorf("FrontGeometryFromCG = %+v, want %+v", got, want)
+	}
+
+	r = &applescripttest.Runner{Respond: applescripttest.Outputs(fallbackCGOutput)}
+	if _, err := New(r).FrontGeometryFromCG(context.Background(), "Notes"); err == nil {
+		t.Error("want error for an app without windows")
+	}
+}
+
+func TestListAllFromCG(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(fallbackCGOutput)}
+	got, err := New(r).ListAllFromCG(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[1] != (WindowInfo{AppName: "Terminal", WindowTitle: "zsh", X: 700, Y: 25, Width: 740, Height: 875}) {
+		t.Errorf("ListAllFromCG = %+v", got)
+	}
+}
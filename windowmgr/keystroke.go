@@ -0,0 +1,144 @@
+package windowmgr
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript"
+)
+
+// keyCodes maps named keys to macOS virtual key codes for System Events'
+// `key code`. Single characters are typed with `keystroke` instead.
+var keyCodes = map[string]int{
+	"return":         36,
+	"enter":          76,
+	"tab":            48,
+	"space":          49,
+	"delete":         51,
+	"escape":         53,
+	"forward-delete": 117,
+	"home":           115,
+	"end":            119,
+	"page-up":        116,
+	"page-down":      121,
+	"left":           123,
+	"right":          124,
+	"down":           125,
+	"up":             126,
+	"f1":             122,
+	"f2":             120,
+	"f3":             99,
+	"f4":             118,
+	"f5":             96,
+	"f6":             97,
+	"f7":             98,
+	"f8":             100,
+	"f9":             101,
+	"f10":            109,
+	"f11":            103,
+	"f12":            111,
+}
+
+// modifierNames maps accepted modifier spellings to AppleScript's `using`
+// clause terms.
+var modifierNames = map[string]string{
+	"command": "command down",
+	"cmd":     "command down",
+	"option":  "option down",
+	"alt":     "option down",
+	"control": "control down",
+	"ctrl":    "control down",
+	"shift":   "shift down",
+}
+
+// KeyNames returns the named keys accepted by SendKeystroke, sorted.
+func KeyNames() []string {
+	names := make([]string, 0, len(keyCodes))
+	for name := range keyCodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keystrokeCommand builds the System Events command that presses key with
+// modifiers, e.g. `keystroke "f" using {command down, control down}`.
+func keystrokeCommand(key string, modifiers []string) (string, error) {
+	var cmd string
+	if code, ok := keyCodes[strings.ToLower(key)]; ok {
+		cmd = fmt.Sprintf("key code %d", code)
+	} else if utf8.RuneCountInString(key) == 1 {
+		cmd = "keystroke " + applescript.Quote(key)
+	} else {
+		return "", fmt.Errorf("invalid key: %q (use a single character or one of: %s)", key, strings.Join(KeyNames(), ", "))
+	}
+
+	var using []string
+	seen := make(map[string]bool)
+	for _, m := range modifiers {
+		term, ok := modifierNames[strings.ToLower(m)]
+		if !ok {
+			return "", fmt.Errorf("invalid modifier: %q (valid: command, option, control, shift)", m)
+		}
+		if !seen[term] {
+			using = append(using, term)
+			seen[term] = true
+		}
+	}
+	if len(using) > 0 {
+		cmd += " using {" + strings.Join(using, ", ") + "}"
+	}
+	return cmd, nil
+}
+
+// SendKeystroke brings appName to the front and presses key with modifiers.
+// key is a single character ("f", "[") or a named key ("escape", "tab",
+// "left", "f11"; see KeyNames). If windowIndex is >= 1 that window is raised
+// first so the keystroke reaches it rather than the app's current key window.
+func (c *Client) SendKeystroke(ctx context.Context, appName, key string, modifiers []string, windowIndex int) error {
+	if appName == "" {
+		return fmt.Errorf("appName is required")
+	}
+	if key == "" {
+		return fmt.Errorf("key is required")
+	}
+	if windowIndex < 0 {
+		return fmt.Errorf("windowIndex must be >= 1 (or omitted)")
+	}
+
+	press, err := keystrokeCommand(key, modifiers)
+	if err != nil {
+		return err
+	}
+
+	raise := ""
+	if windowIndex > 0 {
+		raise = fmt.Sprintf(`
+		if (count of windows) < %[1]d then
+			error "Application '" & appName & "' does not have window %[1]d."
+		end if
+		perform action "AXRaise" of window %[1]d`, windowIndex)
+	}
+
+	// This tool types arbitrary keys, so appName is quoted rather than
+	// pasted into the script.
+	script := fmt.Sprintf(`
+set appName to %[1]s
+tell application "System Events"
+	if not (exists application process appName) then
+		error "Application '" & appName & "' is not running."
+	end if
+	tell application process appName
+		set frontmost to true%[2]s
+		delay 0.1
+		%[3]s
+	end tell
+end tell
+`, applescript.Quote(appName), raise, press)
+
+	_, err = c.scripts.RunAppleScript(ctx, script)
+	return err
+}
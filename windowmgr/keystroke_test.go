@@ -0,0 +1,57 @@
+package windowmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+func TestSendKeystrokeScripts(t *testing.T) {
+	tests := []struct {
+		name        string
+		appName     string
+		key         string
+		modifiers   []string
+		windowIndex int
+	}{
+		{"keystroke_fullscreen", "Safari", "f", []string{"command", "ctrl"}, 2},
+		{"keystroke_escape", "Safari", "Escape", nil, 0},
+		{"keystroke_quote", "Safari", `"`, []string{"shift", "Shift"}, 0},
+		{"keystroke_app_quote", `Evil" & (do shell script "id") & "`, "f", nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &applescripttest.Runner{}
+			if err := New(r).SendKeystroke(context.Background(), tt.appName, tt.key, tt.modifiers, tt.windowIndex); err != nil {
+				t.Fatal(err)
+			}
+			applescripttest.Golden(t, tt.name, r.Scripts()[0])
+		})
+	}
+}
+
+func TestSendKeystrokeRejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name      string
+		appName   string
+		key       string
+		modifiers []string
+	}{
+		{"no app", "", "f", nil},
+		{"no key", "Safari", "", nil},
+		{"unknown named key", "Safari", "hyper", nil},
+		{"unknown modifier", "Safari", "f", []string{"fn"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &applescripttest.Runner{}
+			if err := New(r).SendKeystroke(context.Background(), tt.appName, tt.key, tt.modifiers, 0); err == nil {
+				t.Error("want error")
+			}
+			if n := len(r.Calls()); n != 0 {
+				t.Errorf("ran %d scripts, want 0", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package windowmgr
+
+import "sync"
+
+// snapTolerance is how far a window may come out larger than requested
+// without that being read as a minimum size. Terminals and editors snap to
+// whole character cells (Terminal: 1000 wide becomes 1003).
+const snapTolerance = 20
+
+// Size is a window size in pixels.
+type Size struct {
+	Width  int `json:"width" jsonschema:"Width in pixels"`
+	Height int `json:"height" jsonschema:"Height in pixels"`
+}
+
+// MinSizes remembers per-app minimum window sizes learned from moves where
+// the app refused to shrink a window to the requested size. Apps do not
+// expose their minimum through Accessibility, so this is the only source.
+type MinSizes struct {
+	mu    sync.Mutex
+	sizes map[string]Size
+}
+
+// NewMinSizes returns an empty MinSizes.
+func NewMinSizes() *MinSizes {
+	return &MinSizes{sizes: make(map[string]Size)}
+}
+
+// Learn compares a requested size with the size the window actually ended
+// up with. A window clamped to its minimum comes out exactly at the minimum,
+// so a dimension that overshoots by more than snapTolerance replaces what
+// was learned, lower or higher. A window that ends up smaller than the
+// learned minimum proves it wrong and clears it.
+func (m *MinSizes) Learn(appName string, requested, actual Size) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	learned := m.sizes[appName]
+	learned.Width = learnDim(learned.Width, requested.Width, actual.Width)
+	learned.Height = learnDim(learned.Height, requested.Height, actual.Height)
+	if learned == (Size{}) {
+		delete(m.sizes, appName)
+		return
+	}
+	m.sizes[appName] = learned
+}
+
+// learnDim updates one learned minimum dimension (0 = unknown).
+func learnDim(learned, requested, actual int) int {
+	switch {
+	case actual > requested+snapTolerance:
+		return actual
+	case actual < learned:
+		return 0
+	}
+	return learned
+}
+
+// Get returns the learned minimum for appName. Dimensions that have not been
+// learned are 0.
+func (m *MinSizes) Get(appName string) (Size, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sizes[appName]
+	return s, ok
+}
@@ -0,0 +1,43 @@
+package windowmgr
+
+import "testing"
+
+func TestMinSizesLearn(t *testing.T) {
+	m := NewMinSizes()
+
+	m.Learn("Safari", Size{800, 600}, Size{800, 600})
+	if _, ok := m.Get("Safari"); ok {
+		t.Fatal("learned a minimum from a move that was honored")
+	}
+
+	m.Learn("Safari", Size{200, 600}, Size{500, 600})
+	m.Learn("Safari", Size{800, 100}, Size{800, 300})
+	got, ok := m.Get("Safari")
+	if !ok || got != (Size{500, 300}) {
+		t.Errorf("Get = %+v, %v; want {500 300}", got, ok)
+	}
+
+	// A smaller overshoot shows the minimum is at most that: replace it.
+	m.Learn("Safari", Size{100, 100}, Size{400, 250})
+	if got, _ := m.Get("Safari"); got != (Size{400, 250}) {
+		t.Errorf("after smaller overshoot Get = %+v, want {400 250}", got)
+	}
+
+	// A window smaller than the learned minimum clears that dimension.
+	m.Learn("Safari", Size{300, 800}, Size{300, 800})
+	if got, _ := m.Get("Safari"); got != (Size{0, 250}) {
+		t.Errorf("after honored smaller width Get = %+v, want {0 250}", got)
+	}
+	m.Learn("Safari", Size{300, 200}, Size{300, 200})
+	if _, ok := m.Get("Safari"); ok {
+		t.Error("minimum still known after both dimensions were disproved")
+	}
+}
+
+func TestMinSizesIgnoresCellSnapping(t *testing.T) {
+	m := NewMinSizes()
+	m.Learn("Terminal", Size{1000, 600}, Size{1003, 612})
+	if got, ok := m.Get("Terminal"); ok {
+		t.Errorf("learned %+v from cell snapping, want nothing", got)
+	}
+}
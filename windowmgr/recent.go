@@ -0,0 +1,257 @@
+package windowmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultFocusPollInterval is how often FocusTracker samples the frontmost
+// window while it is in use.
+const DefaultFocusPollInterval = 2 * time.Second
+
+// DefaultFocusIdleTimeout is how long FocusTracker keeps sampling after the
+// last Recent call.
+const DefaultFocusIdleTimeout = 5 * time.Minute
+
+// maxFocusHistory bounds the number of remembered focus events.
+const maxFocusHistory = 50
+
+// CGWindow is a window as reported by the CoreGraphics window list.
+type CGWindow struct {
+	Number   int     `json:"kCGWindowNumber"`
+	OwnerPID int     `json:"kCGWindowOwnerPID"`
+	Owner    string  `json:"kCGWindowOwnerName"`
+	Name     string  `json:"kCGWindowName"`
+	Layer    int     `json:"kCGWindowLayer"`
+	Alpha    float64 `json:"kCGWindowAlpha"`
+	Bounds   struct {
+		X      float64
+		Y      float64
+		Width  float64
+		Height float64
+	} `json:"kCGWindowBounds"`
+}
+
+// RecentWindow is an on-screen window annotated with when it last had focus.
+type RecentWindow struct {
+	WindowID    int    `json:"windowId" jsonschema:"CoreGraphics window number (stable for the window's lifetime)"`
+	AppName     string `json:"appName" jsonschema:"Application name"`
+	PID         int    `json:"pid" jsonschema:"Owning process ID"`
+	WindowTitle string `json:"windowTitle" jsonschema:"Window title (empty without Screen Recording permission)"`
+	X           int    `json:"x" jsonschema:"X position in pixels"`
+	Y           int    `json:"y" jsonschema:"Y position in pixels"`
+	Width       int    `json:"width" jsonschema:"Window width in pixels"`
+	Height      int    `json:"height" jsonschema:"Window height in pixels"`
+	LastFocused string `json:"lastFocused,omitempty" jsonschema:"When the window was last seen focused (RFC 3339), if observed"`
+}
+
+const cgWindowListScript = `
+ObjC.import("CoreGraphics");
+var opts = $.kCGWindowListOptionOnScreenOnly | $.kCGWindowListExcludeDesktopElements;
+JSON.stringify(ObjC.deepUnwrap(ObjC.castRefToObject($.CGWindowListCopyWindowInfo(opts, $.kCGNullWindowID))));
+`
+
+// ListCGWindows returns normal application windows (layer 0, visible) in the
+// window server's front-to-back order. Window titles are only populated when
+// the Screen Recording permission is granted.
+func (c *Client) ListCGWindows(ctx context.Context) ([]CGWindow, error) {
+	out, err := c.scripts.RunJXA(ctx, cgWindowListScript)
+	if err != nil {
+		return nil, err
+	}
+	return parseCGWindowList(out)
+}
+
+func parseCGWindowList(out string) ([]CGWindow, error) {
+	var raw []CGWindow
+	if err := json.Unmarshal([]byte(out), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse window list: %w", err)
+	}
+	var windows []CGWindow
+	for _, w := range raw {
+		if w.Layer != 0 || w.Alpha == 0 {
+			continue
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+type focusEvent struct {
+	windowID int
+	at       time.Time
+}
+
+// FocusTracker remembers which windows were focused, most recent first.
+//
+// The CoreGraphics window list is already ordered front-to-back, which
+// approximates recency across apps. Sampling the frontmost window keeps
+// windows that have since been buried in their most-recently-used place.
+// Sampling starts with the first Recent call and stops once Recent has not
+// been called for the idle timeout, so a server nobody asks for recency
+// runs no background scripts.
+type FocusTracker struct {
+	wm       *Client
+	interval time.Duration
+	idle     time.Duration
+
+	mu       sync.Mutex
+	history  []focusEvent // most recent first
+	sampling bool
+	lastUsed time.Time
+}
+
+// NewFocusTracker returns a FocusTracker that reads the window list with wm.
+func NewFocusTracker(wm *Client) *FocusTracker {
+	return &FocusTracker{wm: wm, interval: DefaultFocusPollInterval, idle: DefaultFocusIdleTimeout}
+}
+
+// Observe records windowID as focused at the given time, moving it to the
+// front of the history.
+func (t *FocusTracker) Observe(windowID int, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.history) > 0 && t.history[0].windowID == windowID {
+		t.history[0].at = at
+		return
+	}
+	history := []focusEvent{{windowID: windowID, at: at}}
+	for _, e := range t.history {
+		if e.windowID != windowID && len(history) < maxFocusHistory {
+			history = append(history, e)
+		}
+	}
+	t.history = history
+}
+
+func (t *FocusTracker) snapshot() []focusEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]focusEvent(nil), t.history...)
+}
+
+// wake records a use and starts the sampler if it is not running.
+func (t *FocusTracker) wake() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastUsed = time.Now()
+	if !t.sampling {
+		t.sampling = true
+		go t.sample()
+	}
+}
+
+// idleSince reports whether Recent has not been called for the idle timeout,
+// and if so marks the sampler stopped.
+func (t *FocusTracker) idleSince(now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now.Sub(t.lastUsed) < t.idle {
+		return false
+	}
+	t.sampling = false
+	return true
+}
+
+// sample records the frontmost window every interval until the tracker goes
+// idle. Errors are ignored: a missed sample only makes the history coarser.
+func (t *FocusTracker) sample() {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if t.idleSince(now) {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), t.interval)
+		if windows, err := t.wm.ListCGWindows(ctx); err == nil && len(windows) > 0 {
+			t.Observe(windows[0].Number, time.Now())
+		}
+		cancel()
+	}
+}
+
+// Recent returns on-screen windows in most-recently-used order, recording the
+// current frontmost window first so the result is never staler than the call
+// itself. It keeps the background sampler running.
+func (t *FocusTracker) Recent(ctx context.Context) ([]RecentWindow, error) {
+	t.wake()
+	windows, err := t.wm.ListCGWindows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(windows) > 0 {
+		t.Observe(windows[0].Number, time.Now())
+	}
+	return orderByRecency(windows, t.snapshot()), nil
+}
+
+// orderByRecency puts windows with recorded focus first (most recent first),
+// followed by the rest in front-to-back order.
+func orderByRecency(windows []CGWindow, history []focusEvent) []RecentWindow {
+	byID := make(map[int]CGWindow, len(windows))
+	for _, w := range windows {
+		byID[w.Number] = w
+	}
+
+	toRecent := func(w CGWindow) RecentWindow {
+		return RecentWindow{
+			WindowID:    w.Number,
+			AppName:     w.Owner,
+			PID:         w.OwnerPID,
+			WindowTitle: w.Name,
+			X:           int(w.Bounds.X),
+			Y:           int(w.Bounds.Y),
+			Width:       int(w.Bounds.Width),
+			Height:      int(w.Bounds.Height),
+		}
+	}
+
+	var ordered []RecentWindow
+	seen := make(map[int]bool)
+	for _, e := range history {
+		w, ok := byID[e.windowID]
+		if !ok || seen[e.windowID] {
+			continue
+		}
+		rw := toRecent(w)
+		rw.LastFocused = e.at.Format(time.RFC3339)
+		ordered = append(ordered, rw)
+		seen[e.windowID] = true
+	}
+	for _, w := range windows {
+		if !seen[w.Number] {
+			ordered = append(ordered, toRecent(w))
+		}
+	}
+	return ordered
+}
+
+// SortByRecency reorders System Events windows to match the recency order.
+// The two sources share no window ID, so windows are matched on owner and
+// frame; unmatched windows keep their relative order at the end.
+func SortByRecency(windows []WindowInfo, recent []RecentWindow) {
+	key := func(app string, x, y, w, h int) string {
+		return fmt.Sprintf("%s|%d|%d|%d|%d", app, x, y, w, h)
+	}
+	rank := make(map[string]int, len(recent))
+	for i, r := range recent {
+		k := key(r.AppName, r.X, r.Y, r.Width, r.Height)
+		if _, ok := rank[k]; !ok {
+			rank[k] = i
+		}
+	}
+	rankOf := func(w WindowInfo) int {
+		if r, ok := rank[key(w.AppName, w.X, w.Y, w.Width, w.Height)]; ok {
+			return r
+		}
+		return len(recent)
+	}
+	sort.SliceStable(windows, func(i, j int) bool {
+		return rankOf(windows[i]) < rankOf(windows[j])
+	})
+}
@@ -0,0 +1,160 @@
+package windowmgr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+const cgListOutput = `[
+ {"kCGWindowNumber": 11, "kCGWindowOwnerPID": 100, "kCGWindowOwnerName": "Safari", "kCGWindowName": "Apple", "kCGWindowLayer": 0, "kCGWindowAlpha": 1,
+  "kCGWindowBounds": {"X": 0, "Y": 25, "Width": 800, "Height": 600}},
+ {"kCGWindowNumber": 12, "kCGWindowOwnerPID": 200, "kCGWindowOwnerName": "Dock", "kCGWindowLayer": 20, "kCGWindowAlpha": 1,
+  "kCGWindowBounds": {"X": 0, "Y": 0, "Width": 1440, "Height": 900}},
+ {"kCGWindowNumber": 13, "kCGWindowOwnerPID": 300, "kCGWindowOwnerName": "Terminal", "kCGWindowName": "zsh", "kCGWindowLayer": 0, "kCGWindowAlpha": 1,
+  "kCGWindowBounds": {"X": 800, "Y": 25, "Width": 640, "Height": 480}},
+ {"kCGWindowNumber": 14, "kCGWindowOwnerPID": 400, "kCGWindowOwnerName": "Hidden", "kCGWindowLayer": 0, "kCGWindowAlpha": 0,
+  "kCGWindowBounds": {"X": 0, "Y": 0, "Width": 10, "Height": 10}}
+]`
+
+func TestParseCGWindowListKeepsNormalVisibleWindows(t *testing.T) {
+	windows, err := parseCGWindowList(cgListOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int
+	for _, w := range windows {
+		ids = append(ids, w.Number)
+	}
+	if want := []int{11, 13}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("window numbers = %v, want %v", ids, want)
+	}
+	if b := windows[1].Bounds; b.X != 800 || b.Width != 640 {
+		t.Errorf("bounds = %+v, want X=800 Width=640", b)
+	}
+}
+
+func TestFocusTrackerObserve(t *testing.T) {
+	ft := NewFocusTracker(nil)
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ft.Observe(1, t0)
+	ft.Observe(2, t0.Add(time.Second))
+	ft.Observe(1, t0.Add(2*time.Second))
+	ft.Observe(1, t0.Add(3*time.Second))
+
+	var ids []int
+	for _, e := range ft.snapshot() {
+		ids = append(ids, e.windowID)
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("history = %v, want %v", ids, want)
+	}
+	if got := ft.snapshot()[0].at; !got.Equal(t0.Add(3 * time.Second)) {
+		t.Errorf("latest focus time = %v, want %v", got, t0.Add(3*time.Second))
+	}
+
+	for i := 0; i < maxFocusHistory+10; i++ {
+		ft.Observe(100+i, t0)
+	}
+	if n := len(ft.snapshot()); n != maxFocusHistory {
+		t.Errorf("history length = %d, want %d", n, maxFocusHistory)
+	}
+}
+
+func TestRecentPutsFocusedWindowsFirst(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(cgListOutput)}
+	ft := NewFocusTracker(New(r))
+	// Terminal was focused earlier; Safari (frontmost now) is observed by Recent.
+	ft.Observe(13, time.Now().Add(-time.Minute))
+
+	got, err := ft.Recent(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var apps []string
+	for _, w := range got {
+		apps = append(apps, w.AppName)
+		if w.LastFocused == "" {
+			t.Errorf("%s: LastFocused is empty", w.AppName)
+		}
+	}
+	if want := []string{"Safari", "Terminal"}; !reflect.DeepEqual(apps, want) {
+		t.Errorf("order = %v, want %v", apps, want)
+	}
+}
+
+func TestFocusTrackerSamplesOnlyWhileUsed(t *testing.T) {
+	r := &applescripttest.Runner{Respond: func(applescripttest.Call) (string, error) { return cgListOutput, nil }}
+	ft := NewFocusTracker(New(r))
+	ft.interval = 5 * time.Millisecond
+	ft.idle = 20 * time.Millisecond
+
+	time.Sleep(20 * time.Millisecond)
+	if n := len(r.Calls()); n != 0 {
+		t.Fatalf("%d calls before first use, want none", n)
+	}
+
+	if _, err := ft.Recent(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		ft.mu.Lock()
+		sampling := ft.sampling
+		ft.mu.Unlock()
+		if !sampling {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("sampler still running after idle timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := len(r.Calls()); n < 2 {
+		t.Errorf("%d calls, want Recent plus background samples", n)
+	}
+	stopped := len(r.Calls())
+	time.Sleep(30 * time.Millisecond)
+	if n := len(r.Calls()); n != stopped {
+		t.Errorf("%d calls after stopping, want %d", n, stopped)
+	}
+}
+
+func TestOrderByRecencyAppendsUnfocusedInZOrder(t *testing.T) {
+	windows, err := parseCGWindowList(cgListOutput)
+	if err != nil {
+		t.Fatal(err)
+	}
+	history := []focusEvent{{windowID: 13, at: time.Now()}, {windowID: 99, at: time.Now()}}
+	got := orderByRecency(windows, history)
+	if len(got) != 2 || got[0].WindowID != 13 || got[1].WindowID != 11 {
+		t.Fatalf("order = %+v, want windows 13 then 11", got)
+	}
+	if got[1].LastFocused != "" {
+		t.Errorf("never-focused window has LastFocused %q", got[1].LastFocused)
+	}
+}
+
+func TestSortByRecency(t *testing.T) {
+	windows := []WindowInfo{
+		{AppName: "Finder", X: 1, Y: 1, Width: 1, Height: 1},
+		{AppName: "Safari", X: 0, Y: 25, Width: 800, Height: 600},
+		{AppName: "Terminal", X: 800, Y: 25, Width: 640, Height: 480},
+	}
+	recent := []RecentWindow{
+		{AppName: "Terminal", X: 800, Y: 25, Width: 640, Height: 480},
+		{AppName: "Safari", X: 0, Y: 25, Width: 800, Height: 600},
+	}
+	SortByRecency(windows, recent)
+
+	var apps []string
+	for _, w := range windows {
+		apps = append(apps, w.AppName)
+	}
+	if want := []string{"Terminal", "Safari", "Finder"}; !reflect.DeepEqual(apps, want) {
+		t.Errorf("order = %v, want %v", apps, want)
+	}
+}
@@ -0,0 +1,88 @@
+package windowmgr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SavedFrame is a window frame remembered before a preset was applied.
+type SavedFrame struct {
+	WindowID int      `json:"windowId" jsonschema:"CoreGraphics window number"`
+	Before   Geometry `json:"before" jsonschema:"Frame to restore"`
+	After    Geometry `json:"after" jsonschema:"Frame the window had after the preset (read back, so app-enforced sizes are included)"`
+
+	// restored is set while the window is back at its own frame, i.e.
+	// After is the user's frame rather than one a tool applied.
+	restored bool
+}
+
+// RestoreStore remembers pre-preset frames keyed by CoreGraphics window
+// number, which stays stable for a window's lifetime while its System Events
+// index changes with focus.
+type RestoreStore struct {
+	mu     sync.Mutex
+	frames map[int]SavedFrame
+}
+
+// NewRestoreStore returns an empty RestoreStore.
+func NewRestoreStore() *RestoreStore {
+	return &RestoreStore{frames: make(map[int]SavedFrame)}
+}
+
+// Save records f. If the window is still where the previous preset left it,
+// the earlier Before is kept, so chained presets (maximize, then left-half)
+// still restore the user's own frame rather than an intermediate preset.
+func (s *RestoreStore) Save(f SavedFrame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if prev, ok := s.frames[f.WindowID]; ok && !prev.restored && sameFrame(prev.After, f.Before) {
+		f.Before = prev.Before
+	}
+	f.restored = false
+	s.frames[f.WindowID] = f
+}
+
+// sameFrame compares position and size, ignoring the app name.
+func sameFrame(a, b Geometry) bool {
+	return a.X == b.X && a.Y == b.Y && a.Width == b.Width && a.Height == b.Height
+}
+
+// Get returns the frame remembered for windowID.
+func (s *RestoreStore) Get(windowID int) (SavedFrame, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.frames[windowID]
+	return f, ok
+}
+
+// Toggle returns the frame remembered for windowID and replaces it with its
+// inverse, so a second restore re-applies the preset (like the zoom button
+// switching between zoomed and user frames).
+func (s *RestoreStore) Toggle(windowID int) (SavedFrame, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.frames[windowID]
+	if ok {
+		s.frames[windowID] = SavedFrame{WindowID: windowID, Before: f.After, After: f.Before, restored: !f.restored}
+	}
+	return f, ok
+}
+
+// WindowID finds the CoreGraphics window number of appName's window with
+// frame g. System Events exposes no window ID, so the two are matched on
+// owner and frame. Like AppleScript, the owner match ignores case.
+func (c *Client) WindowID(ctx context.Context, appName string, g Geometry) (int, error) {
+	windows, err := c.ListCGWindows(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, w := range windows {
+		if strings.EqualFold(w.Owner, appName) && int(w.Bounds.X) == g.X && int(w.Bounds.Y) == g.Y &&
+			int(w.Bounds.Width) == g.Width && int(w.Bounds.Height) == g.Height {
+			return w.Number, nil
+		}
+	}
+	return 0, fmt.Errorf("no on-screen window of '%s' at (%d,%d) %dx%d", appName, g.X, g.Y, g.Width, g.Height)
+}
@@ -0,0 +1,77 @@
+package windowmgr
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+func TestRestoreStoreToggle(t *testing.T) {
+	s := NewRestoreStore()
+	before := Geometry{AppName: "Safari", X: 100, Y: 100, Width: 800, Height: 600}
+	after := Geometry{AppName: "Safari", X: 0, Y: 25, Width: 1440, Height: 875}
+	s.Save(SavedFrame{WindowID: 42, Before: before, After: after})
+
+	f, ok := s.Toggle(42)
+	if !ok || f.Before != before {
+		t.Fatalf("first toggle = %+v, %v; want restore to %+v", f, ok, before)
+	}
+	f, ok = s.Toggle(42)
+	if !ok || f.Before != after {
+		t.Fatalf("second toggle = %+v, %v; want re-apply %+v", f, ok, after)
+	}
+	if _, ok := s.Toggle(7); ok {
+		t.Error("toggle of unknown window reported a saved frame")
+	}
+}
+
+func TestRestoreStoreChainedPresetsKeepUserFrame(t *testing.T) {
+	s := NewRestoreStore()
+	user := Geometry{AppName: "Safari", X: 100, Y: 100, Width: 800, Height: 600}
+	maximized := Geometry{AppName: "Safari", X: 0, Y: 25, Width: 1440, Height: 875}
+	leftHalf := Geometry{AppName: "Safari", X: 0, Y: 25, Width: 720, Height: 875}
+
+	s.Save(SavedFrame{WindowID: 42, Before: user, After: maximized})
+	s.Save(SavedFrame{WindowID: 42, Before: maximized, After: leftHalf})
+	if f, _ := s.Get(42); f.Before != user || f.After != leftHalf {
+		t.Fatalf("after chained presets = %+v, want %+v -> %+v", f, user, leftHalf)
+	}
+
+	// Restored to the user's frame: the next preset starts from there.
+	s.Toggle(42)
+	s.Save(SavedFrame{WindowID: 42, Before: user, After: maximized})
+	if f, _ := s.Get(42); f.Before != user || f.After != maximized {
+		t.Fatalf("preset after restore = %+v, want %+v -> %+v", f, user, maximized)
+	}
+
+	// The user moved the window themselves: that frame becomes the one to restore.
+	moved := Geometry{AppName: "Safari", X: 300, Y: 200, Width: 900, Height: 700}
+	s.Save(SavedFrame{WindowID: 42, Before: moved, After: leftHalf})
+	if f, _ := s.Get(42); f.Before != moved {
+		t.Fatalf("preset after manual move = %+v, want Before %+v", f, moved)
+	}
+}
+
+func TestWindowIDMatchesOwnerAndFrame(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(`[
+		{"kCGWindowNumber": 10, "kCGWindowOwnerName": "Safari", "kCGWindowLayer": 0, "kCGWindowAlpha": 1, "kCGWindowBounds": {"X": 0, "Y": 25, "Width": 700, "Height": 875}},
+		{"kCGWindowNumber": 11, "kCGWindowOwnerName": "Safari", "kCGWindowLayer": 0, "kCGWindowAlpha": 1, "kCGWindowBounds": {"X": 100, "Y": 100, "Width": 800, "Height": 600}}
+	]`)}
+
+	// AppleScript matches process names case-insensitively; so must this.
+	id, err := New(r).WindowID(context.Background(), "safari", Geometry{X: 100, Y: 100, Width: 800, Height: 600})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 11 {
+		t.Errorf("WindowID = %d, want 11", id)
+	}
+}
+
+func TestWindowIDNoMatch(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(`[]`)}
+	if _, err := New(r).WindowID(context.Background(), "Safari", Geometry{Width: 1}); err == nil {
+		t.Error("want error when no window matches")
+	}
+}
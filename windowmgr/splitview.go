@@ -0,0 +1,99 @@
+package windowmgr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript"
+)
+
+// WindowRef addresses one window of an application.
+type WindowRef struct {
+	AppName     string `json:"appName" jsonschema:"Name of the application"`
+	WindowIndex int    `json:"windowIndex,omitempty" jsonschema:"Window index (1-based, 1 = frontmost window; defaults to 1)"`
+}
+
+func (r WindowRef) index() int {
+	if r.WindowIndex == 0 {
+		return 1
+	}
+	return r.WindowIndex
+}
+
+// Menu paths that tile the front window to the left half in Split View. The
+// Window menu item names are only matched in English.
+const (
+	// macOS 15+ groups tiling under Window > Full Screen Tile.
+	tileLeftSequoia = `click menu item "Left of Screen" of menu 1 of menu item "Full Screen Tile" of menu 1 of menu bar item "Window" of menu bar 1`
+	// Catalina through Sonoma put the item directly in the Window menu.
+	tileLeftLegacy = `click menu item "Tile Window to Left of Screen" of menu 1 of menu bar item "Window" of menu bar 1`
+)
+
+// SplitView puts left and right side by side in native full-screen Split
+// View. The left window is tiled through its Window menu (nativeTiling
+// selects the macOS 15+ menu layout); macOS then shows a picker of other
+// windows for the right half, in which the right window is selected by title.
+//
+// If the picker cannot be driven, the left window stays tiled and the error
+// says so, so the user can finish by clicking the right window.
+func (c *Client) SplitView(ctx context.Context, left, right WindowRef, nativeTiling bool) error {
+	if left.AppName == "" || right.AppName == "" {
+		return fmt.Errorf("appName is required for both windows")
+	}
+	if left.index() < 1 || right.index() < 1 {
+		return fmt.Errorf("windowIndex must be >= 1")
+	}
+	if left.AppName == right.AppName && left.index() == right.index() {
+		return fmt.Errorf("left and right must be different windows")
+	}
+
+	tileLeft := tileLeftLegacy
+	if nativeTiling {
+		tileLeft = tileLeftSequoia
+	}
+
+	// App names are quoted into variables rather than pasted into the
+	// script, so a name containing a quote cannot inject AppleScript.
+	script := fmt.Sprintf(`
+set leftApp to %[1]s
+set rightApp to %[3]s
+tell application "System Events"
+	if not (exists application process leftApp) then
+		error "Application '" & leftApp & "' is not running."
+	end if
+	if not (exists application process rightApp) then
+		error "Application '" & rightApp & "' is not running."
+	end if
+	tell application process rightApp
+		if (count of windows) < %[4]d then
+			error "Application '" & rightApp & "' does not have window %[4]d."
+		end if
+		set rightTitle to name of window %[4]d
+	end tell
+	tell application process leftApp
+		set frontmost to true
+		if (count of windows) < %[2]d then
+			error "Application '" & leftApp & "' does not have window %[2]d."
+		end if
+		perform action "AXRaise" of window %[2]d
+		delay 0.2
+		try
+			%[5]s
+		on error errMsg
+			error "Could not tile '" & leftApp & "' to the left of the screen: " & errMsg
+		end try
+	end tell
+	delay 1
+	try
+		tell application process "Dock"
+			click (first UI element of (entire contents) whose name is rightTitle)
+		end tell
+	on error
+		error "Tiled '" & leftApp & "' to the left, but could not select '" & rightTitle & "' in the Split View picker; click it to finish."
+	end try
+end tell
+`, applescript.Quote(left.AppName), left.index(), applescript.Quote(right.AppName), right.index(), tileLeft)
+
+	_, err := c.scripts.RunAppleScript(ctx, script)
+	return err
+}
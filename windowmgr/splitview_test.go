@@ -0,0 +1,53 @@
+package windowmgr
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+func TestSplitViewScripts(t *testing.T) {
+	tests := []struct {
+		name         string
+		nativeTiling bool
+	}{
+		{"split_view_sequoia", true},
+		{"split_view_legacy", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &applescripttest.Runner{}
+			left := WindowRef{AppName: "Safari"}
+			right := WindowRef{AppName: "Notes", WindowIndex: 2}
+			if err := New(r).SplitView(context.Background(), left, right, tt.nativeTiling); err != nil {
+				t.Fatal(err)
+			}
+			applescripttest.Golden(t, tt.name, r.Scripts()[0])
+		})
+	}
+}
+
+func TestSplitViewQuotesAppNames(t *testing.T) {
+	r := &applescripttest.Runner{}
+	left := WindowRef{AppName: `Evil" & (do shell script "id") & "`}
+	if err := New(r).SplitView(context.Background(), left, WindowRef{AppName: "Notes"}, true); err != nil {
+		t.Fatal(err)
+	}
+	want := `set leftApp to "Evil\" & (do shell script \"id\") & \""`
+	if script := r.Scripts()[0]; !strings.Contains(script, want) {
+		t.Errorf("script does not quote the app name:\n%s", script)
+	}
+}
+
+func TestSplitViewRejectsSameWindow(t *testing.T) {
+	r := &applescripttest.Runner{}
+	err := New(r).SplitView(context.Background(), WindowRef{AppName: "Safari"}, WindowRef{AppName: "Safari", WindowIndex: 1}, true)
+	if err == nil {
+		t.Error("want error for pairing a window with itself")
+	}
+	if n := len(r.Calls()); n != 0 {
+		t.Errorf("ran %d scripts, want 0", n)
+	}
+}
@@ -0,0 +1,238 @@
+package windowmgr
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is how often WatchPoller polls the window list.
+const DefaultWatchInterval = 2 * time.Second
+
+// DefaultWatchPollTimeout bounds one window-list sweep. A System Events
+// sweep over a busy desktop can take several seconds, longer than the
+// interval; ticks that fall due meanwhile are dropped rather than queued.
+const DefaultWatchPollTimeout = 15 * time.Second
+
+// watchFailureThreshold is how many polls in a row must fail before
+// subscribers are told.
+const watchFailureThreshold = 3
+
+// Watch event types.
+const (
+	WindowAppeared    = "appeared"
+	WindowDisappeared = "disappeared"
+)
+
+// WatchSpec selects the windows a Watcher reports on.
+type WatchSpec struct {
+	// AppName matches application names case-insensitively as a substring.
+	// Empty matches every application.
+	AppName string
+	// Title is a regular expression matched against window titles. Empty
+	// matches every title.
+	Title string
+}
+
+// WatchEvent reports a matching window appearing or disappearing.
+type WatchEvent struct {
+	Type   string     `json:"type" jsonschema:"'appeared' or 'disappeared'"`
+	Window WindowInfo `json:"window" jsonschema:"The window (last known frame for disappeared windows)"`
+}
+
+// Watcher diffs successive window lists against a WatchSpec. Windows are
+// identified by application and title, so moving or resizing a window is not
+// reported; several windows with the same title are counted.
+type Watcher struct {
+	appName string
+	title   *regexp.Regexp
+
+	mu      sync.Mutex
+	started bool
+	seen    map[string][]WindowInfo
+}
+
+// NewWatcher returns a Watcher for spec.
+func NewWatcher(spec WatchSpec) (*Watcher, error) {
+	if spec.AppName == "" && spec.Title == "" {
+		return nil, fmt.Errorf("appName or title pattern is required")
+	}
+	w := &Watcher{appName: strings.ToLower(spec.AppName)}
+	if spec.Title != "" {
+		re, err := regexp.Compile(spec.Title)
+		if err != nil {
+			return nil, fmt.Errorf("invalid title pattern: %w", err)
+		}
+		w.title = re
+	}
+	return w, nil
+}
+
+// Matches reports whether win satisfies the watcher's spec.
+func (w *Watcher) Matches(win WindowInfo) bool {
+	if w.appName != "" && !strings.Contains(strings.ToLower(win.AppName), w.appName) {
+		return false
+	}
+	return w.title == nil || w.title.MatchString(win.WindowTitle)
+}
+
+// Update records the current window list and returns what changed since the
+// previous call. The first call only establishes the baseline and returns no
+// events.
+func (w *Watcher) Update(windows []WindowInfo) []WatchEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	current := make(map[string][]WindowInfo)
+	for _, win := range windows {
+		if w.Matches(win) {
+			k := win.AppName + "\x00" + win.WindowTitle
+			current[k] = append(current[k], win)
+		}
+	}
+
+	var events []WatchEvent
+	if w.started {
+		for k, wins := range current {
+			for i := len(w.seen[k]); i < len(wins); i++ {
+				events = append(events, WatchEvent{Type: WindowAppeared, Window: wins[i]})
+			}
+		}
+		for k, wins := range w.seen {
+			for i := len(current[k]); i < len(wins); i++ {
+				events = append(events, WatchEvent{Type: WindowDisappeared, Window: wins[i]})
+			}
+		}
+	}
+	w.started = true
+	w.seen = current
+	return events
+}
+
+// Current returns the matching windows from the last Update.
+func (w *Watcher) Current() []WindowInfo {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var wins []WindowInfo
+	for _, ws := range w.seen {
+		wins = append(wins, ws...)
+	}
+	return wins
+}
+
+// WatchPoller sweeps the window list once per interval on behalf of every
+// subscribed Watcher, so N watches cost one System Events query per tick
+// rather than N. The polling goroutine runs only while there are
+// subscribers.
+type WatchPoller struct {
+	wm       *Client
+	interval time.Duration
+	timeout  time.Duration
+
+	mu      sync.Mutex
+	subs    map[*watchSub]struct{}
+	running bool
+}
+
+type watchSub struct {
+	w       *Watcher
+	onEvent func(WatchEvent)
+	onError func(error)
+}
+
+// NewWatchPoller returns a WatchPoller that lists windows with wm every
+// DefaultWatchInterval, allowing DefaultWatchPollTimeout per sweep.
+func NewWatchPoller(wm *Client) *WatchPoller {
+	return &WatchPoller{
+		wm:       wm,
+		interval: DefaultWatchInterval,
+		timeout:  DefaultWatchPollTimeout,
+		subs:     make(map[*watchSub]struct{}),
+	}
+}
+
+// Subscribe takes a baseline of the window list for w, so windows already
+// open are not reported, then calls onEvent for each change until ctx is
+// cancelled. A single failed poll is skipped: a transient osascript error
+// must not look like every window disappearing. After
+// watchFailureThreshold failures in a row onError is called, and again for
+// every further threshold's worth, until a poll succeeds.
+func (p *WatchPoller) Subscribe(ctx context.Context, w *Watcher, onEvent func(WatchEvent), onError func(error)) error {
+	baseCtx, cancel := context.WithTimeout(ctx, p.timeout)
+	windows, err := p.wm.ListAll(baseCtx)
+	cancel()
+	if err != nil {
+		return err
+	}
+	w.Update(windows)
+
+	sub := &watchSub{w: w, onEvent: onEvent, onError: onError}
+	p.mu.Lock()
+	p.subs[sub] = struct{}{}
+	if !p.running {
+		p.running = true
+		go p.run()
+	}
+	p.mu.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.subs, sub)
+	})
+	return nil
+}
+
+// subscribers returns the current subscriptions, or marks the poller stopped
+// and returns nil when there are none.
+func (p *WatchPoller) subscribers() []*watchSub {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.subs) == 0 {
+		p.running = false
+		return nil
+	}
+	subs := make([]*watchSub, 0, len(p.subs))
+	for s := range p.subs {
+		subs = append(subs, s)
+	}
+	return subs
+}
+
+func (p *WatchPoller) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	failures := 0
+	for range ticker.C {
+		subs := p.subscribers()
+		if subs == nil {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		windows, err := p.wm.ListAll(ctx)
+		cancel()
+		if err != nil {
+			failures++
+			if failures%watchFailureThreshold == 0 {
+				err = fmt.Errorf("window list failed %d times in a row: %w", failures, err)
+				for _, s := range subs {
+					if s.onError != nil {
+						s.onError(err)
+					}
+				}
+			}
+			continue
+		}
+		failures = 0
+		for _, s := range subs {
+			for _, e := range s.w.Update(windows) {
+				s.onEvent(e)
+			}
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package windowmgr
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+func TestNewWatcherValidation(t *testing.T) {
+	if _, err := NewWatcher(WatchSpec{}); err == nil {
+		t.Error("empty spec: want error")
+	}
+	if _, err := NewWatcher(WatchSpec{Title: "("}); err == nil {
+		t.Error("invalid regexp: want error")
+	}
+}
+
+func TestWatcherMatches(t *testing.T) {
+	w, err := NewWatcher(WatchSpec{AppName: "zoom", Title: `^Zoom Meeting`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		win  WindowInfo
+		want bool
+	}{
+		{WindowInfo{AppName: "zoom.us", WindowTitle: "Zoom Meeting"}, true},
+		{WindowInfo{AppName: "zoom.us", WindowTitle: "Zoom"}, false},
+		{WindowInfo{AppName: "Safari", WindowTitle: "Zoom Meeting"}, false},
+	}
+	for _, tt := range tests {
+		if got := w.Matches(tt.win); got != tt.want {
+			t.Errorf("Matches(%+v) = %t, want %t", tt.win, got, tt.want)
+		}
+	}
+}
+
+func eventSummary(events []WatchEvent) []string {
+	var out []string
+	for _, e := range events {
+		out = append(out, e.Type+" "+e.Window.WindowTitle)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestWatcherUpdate(t *testing.T) {
+	w, err := NewWatcher(WatchSpec{AppName: "Terminal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := WindowInfo{AppName: "Terminal", WindowTitle: "a"}
+	b := WindowInfo{AppName: "Terminal", WindowTitle: "b"}
+	other := WindowInfo{AppName: "Safari", WindowTitle: "x"}
+
+	if got := w.Update([]WindowInfo{a, other}); got != nil {
+		t.Errorf("baseline events = %v, want none", got)
+	}
+
+	moved := a
+	moved.X = 500
+	if got := w.Update([]WindowInfo{moved, b, b}); !reflect.DeepEqual(eventSummary(got), []string{"appeared b", "appeared b"}) {
+		t.Errorf("events = %v, want two b appearances", eventSummary(got))
+	}
+	if got := w.Update([]WindowInfo{b}); !reflect.DeepEqual(eventSummary(got), []string{"disappeared a", "disappeared b"}) {
+		t.Errorf("events = %v, want a and one b disappearing", eventSummary(got))
+	}
+	if n := len(w.Current()); n != 1 {
+		t.Errorf("Current has %d windows, want 1", n)
+	}
+}
+
+func TestWatchReportsNewWindows(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(
+		"Finder|Home|0|0|10|10",
+		"Finder|Home|0|0|10|10;zoom.us|Zoom Meeting|0|0|800|600",
+	)}
+	w, err := NewWatcher(WatchSpec{Title: "Zoom Meeting"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan WatchEvent, 4)
+	p := NewWatchPoller(New(r))
+	p.interval = 10 * time.Millisecond
+	if err := p.Subscribe(ctx, w, func(e WatchEvent) { events <- e }, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case e := <-events:
+		if e.Type != WindowAppeared || e.Window.AppName != "zoom.us" {
+			t.Errorf("event = %+v, want zoom.us appeared", e)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no event received")
+	}
+}
+
+func TestWatchPollerSharesOneSweep(t *testing.T) {
+	r := &applescripttest.Runner{Respond: func(applescripttest.Call) (string, error) {
+		return "Finder|Home|0|0|10|10", nil
+	}}
+	p := NewWatchPoller(New(r))
+	p.interval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, spec := range []WatchSpec{{AppName: "finder"}, {Title: "Zoom"}, {AppName: "safari"}} {
+		w, err := NewWatcher(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := p.Subscribe(ctx, w, func(WatchEvent) {}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	baselines := len(r.Calls())
+	time.Sleep(55 * time.Millisecond)
+	cancel()
+	// One sweep per tick for all three watches, not three.
+	if sweeps := len(r.Calls()) - baselines; sweeps > 7 {
+		t.Errorf("%d sweeps in ~5 ticks, want one per tick", sweeps)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		p.mu.Lock()
+		running := p.running
+		p.mu.Unlock()
+		if !running {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("poller still running without subscribers")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWatchPollerReportsRepeatedFailures(t *testing.T) {
+	var calls int
+	r := &applescripttest.Runner{Respond: func(applescripttest.Call) (string, error) {
+		calls++
+		if calls == 1 {
+			return "Finder|Home|0|0|10|10", nil
+		}
+		return "", errors.New("osascript error: timed out")
+	}}
+	p := NewWatchPoller(New(r))
+	p.interval = 5 * time.Millisecond
+	w, err := NewWatcher(WatchSpec{AppName: "finder"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 8)
+	if err := p.Subscribe(ctx, w, func(WatchEvent) {}, func(err error) { errs <- err }); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "3 times in a row") {
+			t.Errorf("err = %v, want failure count", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("repeated failures were not reported")
+	}
+}
@@ -0,0 +1,346 @@
+// Package windowmgr moves, resizes and enumerates application windows on
+// macOS through System Events.
+//
+// Windows are addressed by application process name and a 1-based window
+// index (1 = frontmost window of that application). All calls require the
+// Accessibility permission for the calling process.
+//
+// Create a Client with New(applescript.Exec{}) for real use, or pass the fake
+// from package applescripttest in tests.
+package windowmgr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript"
+)
+
+// Client runs window-management scripts through a ScriptRunner.
+type Client struct {
+	scripts applescript.ScriptRunner
+}
+
+// New returns a Client that executes scripts with r.
+func New(r applescript.ScriptRunner) *Client {
+	return &Client{scripts: r}
+}
+
+// Geometry is the frame of an application window.
+type Geometry struct {
+	AppName string `json:"appName" jsonschema:"Application name"`
+	X       int    `json:"x" jsonschema:"X position in pixels"`
+	Y       int    `json:"y" jsonschema:"Y position in pixels"`
+	Width   int    `json:"width" jsonschema:"Window width in pixels"`
+	Height  int    `json:"height" jsonschema:"Window height in pixels"`
+}
+
+// WindowInfo describes a window in the cross-application listing.
+type WindowInfo struct {
+	AppName     string `json:"appName" jsonschema:"Application name"`
+	WindowTitle string `json:"windowTitle" jsonschema:"Window title/name"`
+	X           int    `json:"x" jsonschema:"X position in pixels"`
+	Y           int    `json:"y" jsonschema:"Y position in pixels"`
+	Width       int    `json:"width" jsonschema:"Window width in pixels"`
+	Height      int    `json:"height" jsonschema:"Window height in pixels"`
+}
+
+// AppWindowInfo describes one window of a single application.
+type AppWindowInfo struct {
+	Title  string `json:"title" jsonschema:"Window title"`
+	Index  int    `json:"index" jsonschema:"Window index (1-based, 1 = frontmost)"`
+	X      int    `json:"x" jsonschema:"X position in pixels"`
+	Y      int    `json:"y" jsonschema:"Y position in pixels"`
+	Width  int    `json:"width" jsonschema:"Window width in pixels"`
+	Height int    `json:"height" jsonschema:"Window height in pixels"`
+}
+
+// MoveResize moves and resizes the frontmost window of appName, bringing the
+// application to the front.
+func (c *Client) MoveResize(ctx context.Context, appName string, x, y, width, height int) error {
+	if appName == "" {
+		return fmt.Errorf("appName is required")
+	}
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("width and height must be > 0")
+	}
+
+	// First set size, then position - this order helps with secondary display positioning
+	script := fmt.Sprintf(`
+tell application "System Events"
+	if not (exists application process "%[1]s") then
+		error "Application '%[1]s' is not running."
+	end if
+	tell application process "%[1]s"
+		set frontmost to true
+		if (count of windows) is 0 then
+			error "Application '%[1]s' has no windows."
+		end if
+		tell window 1
+			set size to {%[4]d, %[5]d}
+			delay 0.1
+			set position to {%[2]d, %[3]d}
+		end tell
+	end tell
+end tell
+`, appName, x, y, width, height)
+
+	_, err := c.scripts.RunAppleScript(ctx, script)
+	return err
+}
+
+// MoveResizeWindow moves and resizes window windowIndex of appName.
+func (c *Client) MoveResizeWindow(ctx context.Context, appName string, windowIndex, x, y, width, height int) error {
+	if appName == "" {
+		return fmt.Errorf("appName is required")
+	}
+	if windowIndex < 1 {
+		return fmt.Errorf("windowIndex must be >= 1")
+	}
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("width and height must be > 0")
+	}
+
+	script := fmt.Sprintf(`
+tell application "System Events"
+	if not (exists application process "%[1]s") then
+		error "Application '%[1]s' is not running."
+	end if
+	tell application process "%[1]s"
+		set frontmost to true
+		if (count of windows) < %[2]d then
+			error "Application '%[1]s' does not have window %[2]d."
+		end if
+		tell window %[2]d
+			set position to {%[3]d, %[4]d}
+			set size to {%[5]d, %[6]d}
+		end tell
+	end tell
+end tell
+`, appName, windowIndex, x, y, width, height)
+
+	_, err := c.scripts.RunAppleScript(ctx, script)
+	return err
+}
+
+// FrontGeometry returns the frame of the frontmost window of appName.
+func (c *Client) FrontGeometry(ctx context.Context, appName string) (Geometry, error) {
+	if appName == "" {
+		return Geometry{}, fmt.Errorf("appName is required")
+	}
+
+	script := fmt.Sprintf(`
+tell application "System Events"
+	if not (exists application process "%[1]s") then
+		error "Application '%[1]s' is not running."
+	end if
+	tell application process "%[1]s"
+		if (count of windows) is 0 then
+			error "Application '%[1]s' has no windows."
+		end if
+		tell window 1
+			set {xPos, yPos} to position
+			set {w, h} to size
+			return xPos & "," & yPos & "," & w & "," & h
+		end tell
+	end tell
+end tell
+`, appName)
+
+	return c.queryGeometry(ctx, appName, script)
+}
+
+// WindowGeometry returns the frame of window windowIndex of appName.
+func (c *Client) WindowGeometry(ctx context.Context, appName string, windowIndex int) (Geometry, error) {
+	if appName == "" {
+		return Geometry{}, fmt.Errorf("appName is required")
+	}
+	if windowIndex < 1 {
+		return Geometry{}, fmt.Errorf("windowIndex must be >= 1")
+	}
+
+	script := fmt.Sprintf(`
+tell application "System Events"
+	if not (exists application process "%[1]s") then
+		error "Application '%[1]s' is not running."
+	end if
+	tell application process "%[1]s"
+		if (count of windows) < %[2]d then
+			error "Application '%[1]s' does not have window %[2]d."
+		end if
+		tell window %[2]d
+			set {xPos, yPos} to position
+			set {wWidth, wHeight} to size
+			return xPos & "," & yPos & "," & wWidth & "," & wHeight
+		end tell
+	end tell
+end tell
+`, appName, windowIndex)
+
+	return c.queryGeometry(ctx, appName, script)
+}
+
+func (c *Client) queryGeometry(ctx context.Context, appName, script string) (Geometry, error) {
+	out, err := c.scripts.RunAppleScript(ctx, script)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	vals, err := applescript.ParseCSVInts(out, 4)
+	if err != nil {
+		return Geometry{}, err
+	}
+
+	return Geometry{
+		AppName: appName,
+		X:       vals[0],
+		Y:       vals[1],
+		Width:   vals[2],
+		Height:  vals[3],
+	}, nil
+}
+
+const listAllScript = `
+tell application "System Events"
+	set windowList to {}
+	repeat with proc in (application processes whose visible is true)
+		set appName to name of proc
+		try
+			repeat with w in (windows of proc)
+				try
+					set {x, y} to position of w
+					set {wWidth, wHeight} to size of w
+					set windowTitle to name of w
+					set end of windowList to appName & "|" & windowTitle & "|" & x & "|" & y & "|" & wWidth & "|" & wHeight
+				end try
+			end repeat
+		end try
+	end repeat
+	set AppleScript's text item delimiters to ";"
+	return windowList as text
+end tell
+`
+
+// ListAll returns all windows of all visible application processes, grouped
+// by application.
+func (c *Client) ListAll(ctx context.Context) ([]WindowInfo, error) {
+	out, err := c.scripts.RunAppleScript(ctx, listAllScript)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []WindowInfo
+	if strings.TrimSpace(out) != "" {
+		records := strings.Split(out, ";")
+		for _, record := range records {
+			if strings.TrimSpace(record) == "" {
+				continue
+			}
+			w, err := parseWindowRecord(record)
+			if err != nil {
+				// Skip malformed records rather than failing completely
+				continue
+			}
+			windows = append(windows, w)
+		}
+	}
+	return windows, nil
+}
+
+func parseWindowRecord(record string) (WindowInfo, error) {
+	parts := strings.Split(record, "|")
+	if len(parts) != 6 {
+		return WindowInfo{}, fmt.Errorf("expected 6 pipe-separated values, got %d (%q)", len(parts), record)
+	}
+	x, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+	if err != nil {
+		return WindowInfo{}, fmt.Errorf("invalid x coordinate: %w", err)
+	}
+	y, err := strconv.Atoi(strings.TrimSpace(parts[3]))
+	if err != nil {
+		return WindowInfo{}, fmt.Errorf("invalid y coordinate: %w", err)
+	}
+	width, err := strconv.Atoi(strings.TrimSpace(parts[4]))
+	if err != nil {
+		return WindowInfo{}, fmt.Errorf("invalid width: %w", err)
+	}
+	height, err := strconv.Atoi(strings.TrimSpace(parts[5]))
+	if err != nil {
+		return WindowInfo{}, fmt.Errorf("invalid height: %w", err)
+	}
+	return WindowInfo{
+		AppName:     strings.TrimSpace(parts[0]),
+		WindowTitle: strings.TrimSpace(parts[1]),
+		X:           x,
+		Y:           y,
+		Width:       width,
+		Height:      height,
+	}, nil
+}
+
+// AppWindows returns all windows of appName in front-to-back order.
+func (c *Client) AppWindows(ctx context.Context, appName string) ([]AppWindowInfo, error) {
+	if appName == "" {
+		return nil, fmt.Errorf("appName is required")
+	}
+
+	script := fmt.Sprintf(`
+tell application "System Events"
+	if not (exists application process "%[1]s") then
+		error "Application '%[1]s' is not running."
+	end if
+	tell application process "%[1]s"
+		if (count of windows) is 0 then
+			error "Application '%[1]s' has no windows."
+		end if
+		set windowData to {}
+		repeat with w in windows
+			try
+				set {x, y} to position of w
+				set {wWidth, wHeight} to size of w
+				set windowTitle to name of w
+				set end of windowData to windowTitle & "|" & x & "|" & y & "|" & wWidth & "|" & wHeight
+			end try
+		end repeat
+		set AppleScript's text item delimiters to ";"
+		return windowData as text
+	end tell
+end tell
+`, appName)
+
+	out, err := c.scripts.RunAppleScript(ctx, script)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []AppWindowInfo
+	if strings.TrimSpace(out) != "" {
+		records := strings.Split(out, ";")
+		for idx, record := range records {
+			if strings.TrimSpace(record) == "" {
+				continue
+			}
+			parts := strings.Split(record, "|")
+			if len(parts) != 5 {
+				continue
+			}
+			title := strings.TrimSpace(parts[0])
+			x, _ := strconv.Atoi(strings.TrimSpace(parts[1]))
+			y, _ := strconv.Atoi(strings.TrimSpace(parts[2]))
+			width, _ := strconv.Atoi(strings.TrimSpace(parts[3]))
+			height, _ := strconv.Atoi(strings.TrimSpace(parts[4]))
+
+			windows = append(windows, AppWindowInfo{
+				Title:  title,
+				Index:  idx + 1, // 1-based index
+				X:      x,
+				Y:      y,
+				Width:  width,
+				Height: height,
+			})
+		}
+	}
+	return windows, nil
+}
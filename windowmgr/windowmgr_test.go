@@ -0,0 +1,124 @@
+package windowmgr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bad33ndj3/mcp-macos-window-manager/applescript/applescripttest"
+)
+
+func TestScripts(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		output string
+		call   func(c *Client) error
+	}{
+		{"move_resize", "", func(c *Client) error {
+			return c.MoveResize(ctx, "Safari", 10, 20, 800, 600)
+		}},
+		{"move_resize_window", "", func(c *Client) error {
+			return c.MoveResizeWindow(ctx, "Google Chrome", 2, -1440, 0, 1440, 900)
+		}},
+		{"front_geometry", "10,20,800,600", func(c *Client) error {
+			_, err := c.FrontGeometry(ctx, "Safari")
+			return err
+		}},
+		{"window_geometry", "10,20,800,600", func(c *Client) error {
+			_, err := c.WindowGeometry(ctx, "Safari", 3)
+			return err
+		}},
+		{"list_all", "", func(c *Client) error {
+			_, err := c.ListAll(ctx)
+			return err
+		}},
+		{"app_windows", "", func(c *Client) error {
+			_, err := c.AppWindows(ctx, "Finder")
+			return err
+		}},
+		{"cg_window_list", "[]", func(c *Client) error {
+			_, err := c.ListCGWindows(ctx)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &applescripttest.Runner{Respond: applescripttest.Outputs(tt.output)}
+			if err := tt.call(New(r)); err != nil {
+				t.Fatal(err)
+			}
+			scripts := r.Scripts()
+			if len(scripts) != 1 {
+				t.Fatalf("got %d scripts, want 1", len(scripts))
+			}
+			applescripttest.Golden(t, tt.name, scripts[0])
+		})
+	}
+}
+
+func TestValidationRunsNoScript(t *testing.T) {
+	ctx := context.Background()
+	r := &applescripttest.Runner{}
+	c := New(r)
+
+	if err := c.MoveResize(ctx, "", 0, 0, 100, 100); err == nil {
+		t.Error("MoveResize with empty appName: want error")
+	}
+	if err := c.MoveResize(ctx, "Safari", 0, 0, 0, 100); err == nil {
+		t.Error("MoveResize with zero width: want error")
+	}
+	if err := c.MoveResizeWindow(ctx, "Safari", 0, 0, 0, 100, 100); err == nil {
+		t.Error("MoveResizeWindow with windowIndex 0: want error")
+	}
+	if _, err := c.WindowGeometry(ctx, "Safari", -1); err == nil {
+		t.Error("WindowGeometry with negative index: want error")
+	}
+	if n := len(r.Calls()); n != 0 {
+		t.Errorf("ran %d scripts for invalid input, want 0", n)
+	}
+}
+
+func TestWindowGeometry(t *testing.T) {
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs("-1440, 25, 1440, 875")}
+	got, err := New(r).WindowGeometry(context.Background(), "Safari", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Geometry{AppName: "Safari", X: -1440, Y: 25, Width: 1440, Height: 875}
+	if got != want {
+		t.Errorf("WindowGeometry = %+v, want %+v", got, want)
+	}
+}
+
+func TestListAllSkipsMalformedRecords(t *testing.T) {
+	out := "Safari|Apple|0|25|800|600;broken|record;Finder|Home|10|10|100|x;Terminal|zsh|5|6|7|8"
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(out)}
+	got, err := New(r).ListAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []WindowInfo{
+		{AppName: "Safari", WindowTitle: "Apple", X: 0, Y: 25, Width: 800, Height: 600},
+		{AppName: "Terminal", WindowTitle: "zsh", X: 5, Y: 6, Width: 7, Height: 8},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListAll = %+v, want %+v", got, want)
+	}
+}
+
+func TestAppWindowsIndexes(t *testing.T) {
+	out := "Inbox|0|25|800|600;Drafts|100|125|640|480"
+	r := &applescripttest.Runner{Respond: applescripttest.Outputs(out)}
+	got, err := New(r).AppWindows(context.Background(), "Mail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []AppWindowInfo{
+		{Title: "Inbox", Index: 1, X: 0, Y: 25, Width: 800, Height: 600},
+		{Title: "Drafts", Index: 2, X: 100, Y: 125, Width: 640, Height: 480},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AppWindows = %+v, want %+v", got, want)
+	}
+}